@@ -0,0 +1,622 @@
+// https://yandex.ru/dev/api360/doc/
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"strconv"
+)
+
+const admin360URL = "https://api360.yandex.net"
+
+// Admin360 is a client of the Yandex 360 Admin API which replaces the Directory API.
+// Its URLs are scoped to an organization, the calls go through the same chain as Directory calls:
+// retries, limiter, interceptors, logger and tracer, errors are *APIError.
+type Admin360 struct {
+	core Directory
+}
+
+// NewAdmin360 takes the same options as NewDirectory, WithBaseURL replaces https://api360.yandex.net
+func NewAdmin360(client *http.Client, opts ...DirectoryOption) *Admin360 {
+	d := NewDirectory(client, append([]DirectoryOption{WithBaseURL(admin360URL)}, opts...)...)
+	return &Admin360{core: *d}
+}
+
+// BaseURL returns the URL all endpoints are built from
+func (a Admin360) BaseURL() string {
+	return a.core.baseURL
+}
+
+// endpoint builds an URL under /directory/v1/org/{org}, zero orgID is the default one
+func (a Admin360) endpoint(orgID int, template string, args ...interface{}) endpoint {
+	if orgID == 0 {
+		orgID = a.core.orgID
+	}
+	return a.core.endpoint("/directory/v1/org/{org}"+template, append([]interface{}{orgID}, args...)...)
+}
+
+// request sends the call, the 360 API answers 200 to every successful call
+// and takes the organization from the URL instead of X-Org-ID
+func (a Admin360) request(ctx context.Context, method string, ep endpoint, params Parameters, body interface{}, v interface{}) error {
+	var j []byte
+	if body != nil {
+		var err error
+		if j, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	return a.core.request(ctx, method, ep, params, noOrgID, http.StatusOK, j, v)
+}
+
+// page360 decodes a 360 list, its items are under a key named after the resource
+type page360[T any] struct {
+	key  string
+	page Page[T]
+}
+
+func (p *page360[T]) UnmarshalJSON(b []byte) error {
+	var meta struct {
+		Page    int `json:"page"`
+		Pages   int `json:"pages"`
+		PerPage int `json:"perPage"`
+		Total   int `json:"total"`
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return err
+	}
+	var items map[string]json.RawMessage
+	if err := json.Unmarshal(b, &items); err != nil {
+		return err
+	}
+	p.page = Page[T]{Page: meta.Page, Pages: meta.Pages, PerPage: meta.PerPage, Total: meta.Total}
+	if raw, ok := items[p.key]; ok {
+		return json.Unmarshal(raw, &p.page.Result)
+	}
+	return nil
+}
+
+// list360 gets one page of the list at ep
+func list360[T any](ctx context.Context, a Admin360, ep endpoint, key string, params Parameters) (Page[T], error) {
+	p := page360[T]{key: key}
+	err := a.request(ctx, http.MethodGet, ep, params, nil, &p)
+	return p.page, err
+}
+
+// pages360 fetches pages of the list at ep, pages are selected by the page parameter
+// and sized by perPage
+func pages360[T any](a Admin360, ep endpoint, key string) pageFetcher[T] {
+	return func(ctx context.Context, params Parameters) (Page[T], error) {
+		return list360[T](ctx, a, ep, key, params)
+	}
+}
+
+// object360 sends the call and decodes the object it returns
+func object360[T any](ctx context.Context, a Admin360, method string, ep endpoint, body interface{}) (T, error) {
+	var v T
+	err := a.request(ctx, method, ep, nil, body, &v)
+	return v, err
+}
+
+// User360 is a user of the 360 API
+type User360 struct {
+	ID                     string                 `json:"id,omitempty"`
+	Nickname               string                 `json:"nickname,omitempty"`
+	DepartmentID           int                    `json:"departmentId,omitempty"`
+	Email                  string                 `json:"email,omitempty"`
+	Name                   *DirectoryUserName     `json:"name,omitempty"`
+	Gender                 string                 `json:"gender,omitempty"`
+	Position               string                 `json:"position,omitempty"`
+	About                  string                 `json:"about,omitempty"`
+	Birthday               string                 `json:"birthday,omitempty"`
+	ExternalID             string                 `json:"externalId,omitempty"`
+	IsAdmin                bool                   `json:"isAdmin,omitempty"`
+	IsRobot                bool                   `json:"isRobot,omitempty"`
+	IsDismissed            bool                   `json:"isDismissed,omitempty"`
+	IsEnabled              bool                   `json:"isEnabled,omitempty"`
+	Timezone               string                 `json:"timezone,omitempty"`
+	Language               string                 `json:"language,omitempty"`
+	Contacts               []DirectoryUserContact `json:"contacts,omitempty"`
+	Aliases                []string               `json:"aliases,omitempty"`
+	Groups                 []int                  `json:"groups,omitempty"`
+	Password               string                 `json:"password,omitempty"`
+	PasswordChangeRequired bool                   `json:"passwordChangeRequired,omitempty"`
+	CreatedAt              string                 `json:"createdAt,omitempty"`
+	UpdatedAt              string                 `json:"updatedAt,omitempty"`
+}
+
+// Department360 is a department of the 360 API
+type Department360 struct {
+	ID           int      `json:"id,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	ParentID     int      `json:"parentId,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	ExternalID   string   `json:"externalId,omitempty"`
+	Label        string   `json:"label,omitempty"`
+	Email        string   `json:"email,omitempty"`
+	HeadID       string   `json:"headId,omitempty"`
+	MembersCount int      `json:"membersCount,omitempty"`
+	Aliases      []string `json:"aliases,omitempty"`
+	CreatedAt    string   `json:"createdAt,omitempty"`
+}
+
+// Group360 is a group of the 360 API
+type Group360 struct {
+	ID           int              `json:"id,omitempty"`
+	Name         string           `json:"name,omitempty"`
+	Type         string           `json:"type,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	Label        string           `json:"label,omitempty"`
+	Email        string           `json:"email,omitempty"`
+	ExternalID   string           `json:"externalId,omitempty"`
+	Removed      bool             `json:"removed,omitempty"`
+	MembersCount int              `json:"membersCount,omitempty"`
+	Members      []Group360Member `json:"members,omitempty"`
+	AdminIDs     []string         `json:"adminIds,omitempty"`
+	AuthorID     string           `json:"authorId,omitempty"`
+	MemberOf     []int            `json:"memberOf,omitempty"`
+	Aliases      []string         `json:"aliases,omitempty"`
+	CreatedAt    string           `json:"createdAt,omitempty"`
+}
+
+// Group360Member is a member of a 360 group, IDs are strings in the 360 API
+type Group360Member struct {
+	Type MemberType `json:"type"`
+	ID   string     `json:"id"`
+}
+
+// User360Patch is a partial update of a 360 user: only non-nil fields are sent,
+// so false, zero and empty values can be set too
+type User360Patch struct {
+	Name                   *DirectoryUserName      `json:"name,omitempty"`
+	Position               *string                 `json:"position,omitempty"`
+	About                  *string                 `json:"about,omitempty"`
+	Gender                 *string                 `json:"gender,omitempty"`
+	Birthday               *string                 `json:"birthday,omitempty"`
+	DepartmentID           *int                    `json:"departmentId,omitempty"`
+	Contacts               *[]DirectoryUserContact `json:"contacts,omitempty"`
+	ExternalID             *string                 `json:"externalId,omitempty"`
+	IsAdmin                *bool                   `json:"isAdmin,omitempty"`
+	IsDismissed            *bool                   `json:"isDismissed,omitempty"`
+	IsEnabled              *bool                   `json:"isEnabled,omitempty"`
+	Timezone               *string                 `json:"timezone,omitempty"`
+	Language               *string                 `json:"language,omitempty"`
+	Password               *string                 `json:"password,omitempty"`
+	PasswordChangeRequired *bool                   `json:"passwordChangeRequired,omitempty"`
+}
+
+// Department360Patch is a partial update of a 360 department, only non-nil fields are sent
+type Department360Patch struct {
+	Name        *string `json:"name,omitempty"`
+	Label       *string `json:"label,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ParentID    *int    `json:"parentId,omitempty"`
+	HeadID      *string `json:"headId,omitempty"`
+	ExternalID  *string `json:"externalId,omitempty"`
+}
+
+// Group360Patch is a partial update of a 360 group, only non-nil fields are sent
+type Group360Patch struct {
+	Name        *string           `json:"name,omitempty"`
+	Label       *string           `json:"label,omitempty"`
+	Description *string           `json:"description,omitempty"`
+	ExternalID  *string           `json:"externalId,omitempty"`
+	Members     *[]Group360Member `json:"members,omitempty"`
+	AdminIDs    *[]string         `json:"adminIds,omitempty"`
+}
+
+// Domain360 is a domain of the 360 API
+type Domain360 struct {
+	Name      string `json:"name"`
+	Country   string `json:"country,omitempty"`
+	Mx        bool   `json:"mx"`
+	Delegated bool   `json:"delegated"`
+	Master    bool   `json:"master"`
+	Verified  bool   `json:"verified"`
+}
+
+// id360 parses a 360 string ID, zero if it is not a number
+func id360(id string) int {
+	n, _ := strconv.Atoi(id)
+	return n
+}
+
+// idString formats a Directory ID for the 360 API, empty for zero
+func idString(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// externalID formats a Directory external ID which may be a string or a number
+func externalID(id interface{}) string {
+	switch v := id.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		// encoding/json decodes numbers into interface{} as float64
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	}
+	return fmt.Sprint(id)
+}
+
+// DirectoryUser converts the user to the Directory shape
+func (u User360) DirectoryUser() DirectoryUser {
+	user := DirectoryUser{
+		ID:           id360(u.ID),
+		Nickname:     u.Nickname,
+		DepartmentID: u.DepartmentID,
+		Email:        u.Email,
+		Name:         u.Name,
+		Gender:       u.Gender,
+		Position:     u.Position,
+		About:        u.About,
+		Birthday:     u.Birthday,
+		IsAdmin:      u.IsAdmin,
+		IsRobot:      u.IsRobot,
+		IsDismissed:  u.IsDismissed,
+		Contacts:     u.Contacts,
+		Aliases:      u.Aliases,
+		Password:     u.Password,
+		Created:      u.CreatedAt,
+	}
+	if u.ExternalID != "" {
+		user.ExternalID = u.ExternalID
+	}
+	if u.PasswordChangeRequired {
+		user.PasswordChangeRequired = "true"
+	}
+	for _, id := range u.Groups {
+		user.Groups = append(user.Groups, DirectoryUserGroup{ID: id})
+	}
+	return user
+}
+
+// User360FromDirectory converts the Directory user to the 360 shape
+func User360FromDirectory(u DirectoryUser) User360 {
+	user := User360{
+		ID:                     idString(u.ID),
+		Nickname:               u.Nickname,
+		DepartmentID:           u.DepartmentID,
+		Email:                  u.Email,
+		Name:                   u.Name,
+		Gender:                 u.Gender,
+		Position:               u.Position,
+		About:                  u.About,
+		Birthday:               u.Birthday,
+		ExternalID:             externalID(u.ExternalID),
+		IsAdmin:                u.IsAdmin,
+		IsRobot:                u.IsRobot,
+		IsDismissed:            u.IsDismissed,
+		Contacts:               u.Contacts,
+		Aliases:                u.Aliases,
+		Password:               u.Password,
+		PasswordChangeRequired: u.PasswordChangeRequired == "true",
+		CreatedAt:              u.Created,
+	}
+	if user.DepartmentID == 0 && u.Department != nil {
+		user.DepartmentID = u.Department.ID
+	}
+	for _, g := range u.Groups {
+		user.Groups = append(user.Groups, g.ID)
+	}
+	return user
+}
+
+// DirectoryDepartment converts the department to the Directory shape
+func (dep Department360) DirectoryDepartment() DirectoryDepartment {
+	department := DirectoryDepartment{
+		ID:           dep.ID,
+		Name:         dep.Name,
+		Description:  dep.Description,
+		Label:        dep.Label,
+		Email:        dep.Email,
+		MembersCount: dep.MembersCount,
+		Aliases:      dep.Aliases,
+		Created:      dep.CreatedAt,
+		Parent:       DirectoryDepartmentParent{ID: dep.ParentID},
+		Head:         directoryID{ID: id360(dep.HeadID)},
+	}
+	if dep.ExternalID != "" {
+		department.ExternalID = dep.ExternalID
+	}
+	return department
+}
+
+// Department360FromDirectory converts the Directory department to the 360 shape
+func Department360FromDirectory(dep DirectoryDepartment) Department360 {
+	return Department360{
+		ID:           dep.ID,
+		Name:         dep.Name,
+		ParentID:     dep.Parent.ID,
+		Description:  dep.Description,
+		ExternalID:   externalID(dep.ExternalID),
+		Label:        dep.Label,
+		Email:        dep.Email,
+		HeadID:       idString(dep.Head.ID),
+		MembersCount: dep.MembersCount,
+		Aliases:      dep.Aliases,
+		CreatedAt:    dep.Created,
+	}
+}
+
+// DirectoryGroup converts the group to the Directory shape
+func (g Group360) DirectoryGroup() DirectoryGroup {
+	group := DirectoryGroup{
+		ID:           g.ID,
+		Name:         g.Name,
+		Type:         g.Type,
+		Description:  g.Description,
+		Label:        g.Label,
+		Email:        g.Email,
+		ExternalID:   g.ExternalID,
+		MembersCount: g.MembersCount,
+		MemberOf:     g.MemberOf,
+		Aliases:      g.Aliases,
+		Created:      g.CreatedAt,
+		Author:       DirectoryGroupUser{ID: id360(g.AuthorID)},
+	}
+	for _, m := range g.Members {
+		group.Members = append(group.Members, DirectoryGroupMember{Type: m.Type, ID: id360(m.ID)})
+	}
+	for _, id := range g.AdminIDs {
+		group.Admins = append(group.Admins, DirectoryGroupUser{ID: id360(id)})
+	}
+	return group
+}
+
+// Group360FromDirectory converts the Directory group to the 360 shape
+func Group360FromDirectory(g DirectoryGroup) Group360 {
+	group := Group360{
+		ID:           g.ID,
+		Name:         g.Name,
+		Type:         g.Type,
+		Description:  g.Description,
+		Label:        g.Label,
+		Email:        g.Email,
+		ExternalID:   g.ExternalID,
+		MembersCount: g.MembersCount,
+		MemberOf:     g.MemberOf,
+		Aliases:      g.Aliases,
+		CreatedAt:    g.Created,
+		AuthorID:     idString(g.Author.ID),
+	}
+	for _, m := range g.Members {
+		group.Members = append(group.Members, Group360Member{Type: m.Type, ID: strconv.Itoa(m.ID)})
+	}
+	for _, admin := range g.Admins {
+		group.AdminIDs = append(group.AdminIDs, strconv.Itoa(admin.ID))
+	}
+	return group
+}
+
+// Users
+
+// ListUsers returns one page of users, params are page and perPage
+func (a Admin360) ListUsers(orgID int, params Parameters) (Page[User360], error) {
+	return a.ListUsersContext(context.Background(), orgID, params)
+}
+
+// ListUsersContext ...
+func (a Admin360) ListUsersContext(ctx context.Context, orgID int, params Parameters) (Page[User360], error) {
+	return list360[User360](ctx, a, a.endpoint(orgID, "/users"), "users", params)
+}
+
+// AllUsers iterates over users of all pages
+func (a Admin360) AllUsers(ctx context.Context, orgID int, params Parameters) iter.Seq2[User360, error] {
+	return allItems(ctx, pages360[User360](a, a.endpoint(orgID, "/users"), "users"), params)
+}
+
+// ListAllUsers fetches users of all pages with up to workers concurrent calls
+func (a Admin360) ListAllUsers(ctx context.Context, orgID int, params Parameters, workers int) ([]User360, error) {
+	return fetchItems(ctx, pages360[User360](a, a.endpoint(orgID, "/users"), "users"), params, workers)
+}
+
+// GetUser ...
+func (a Admin360) GetUser(orgID int, userID string) (User360, error) {
+	return a.GetUserContext(context.Background(), orgID, userID)
+}
+
+// GetUserContext ...
+func (a Admin360) GetUserContext(ctx context.Context, orgID int, userID string) (User360, error) {
+	return object360[User360](ctx, a, http.MethodGet, a.endpoint(orgID, "/users/{id}", userID), nil)
+}
+
+// CreateUser ...
+func (a Admin360) CreateUser(orgID int, user User360) (User360, error) {
+	return a.CreateUserContext(context.Background(), orgID, user)
+}
+
+// CreateUserContext ...
+func (a Admin360) CreateUserContext(ctx context.Context, orgID int, user User360) (User360, error) {
+	return object360[User360](ctx, a, http.MethodPost, a.endpoint(orgID, "/users"), user)
+}
+
+// UpdateUser changes the non-nil fields of the patch
+func (a Admin360) UpdateUser(orgID int, userID string, patch User360Patch) (User360, error) {
+	return a.UpdateUserContext(context.Background(), orgID, userID, patch)
+}
+
+// UpdateUserContext ...
+func (a Admin360) UpdateUserContext(ctx context.Context, orgID int, userID string, patch User360Patch) (User360, error) {
+	return object360[User360](ctx, a, http.MethodPatch, a.endpoint(orgID, "/users/{id}", userID), patch)
+}
+
+// Departments
+
+// ListDepartments returns one page of departments, params are page and perPage
+func (a Admin360) ListDepartments(orgID int, params Parameters) (Page[Department360], error) {
+	return a.ListDepartmentsContext(context.Background(), orgID, params)
+}
+
+// ListDepartmentsContext ...
+func (a Admin360) ListDepartmentsContext(ctx context.Context, orgID int, params Parameters) (Page[Department360], error) {
+	return list360[Department360](ctx, a, a.endpoint(orgID, "/departments"), "departments", params)
+}
+
+// AllDepartments iterates over departments of all pages
+func (a Admin360) AllDepartments(ctx context.Context, orgID int, params Parameters) iter.Seq2[Department360, error] {
+	return allItems(ctx, pages360[Department360](a, a.endpoint(orgID, "/departments"), "departments"), params)
+}
+
+// ListAllDepartments fetches departments of all pages with up to workers concurrent calls
+func (a Admin360) ListAllDepartments(ctx context.Context, orgID int, params Parameters, workers int) ([]Department360, error) {
+	return fetchItems(ctx, pages360[Department360](a, a.endpoint(orgID, "/departments"), "departments"), params, workers)
+}
+
+// GetDepartment ...
+func (a Admin360) GetDepartment(orgID int, depID int) (Department360, error) {
+	return a.GetDepartmentContext(context.Background(), orgID, depID)
+}
+
+// GetDepartmentContext ...
+func (a Admin360) GetDepartmentContext(ctx context.Context, orgID int, depID int) (Department360, error) {
+	return object360[Department360](ctx, a, http.MethodGet, a.endpoint(orgID, "/departments/{id}", depID), nil)
+}
+
+// CreateDepartment ...
+func (a Admin360) CreateDepartment(orgID int, dep Department360) (Department360, error) {
+	return a.CreateDepartmentContext(context.Background(), orgID, dep)
+}
+
+// CreateDepartmentContext ...
+func (a Admin360) CreateDepartmentContext(ctx context.Context, orgID int, dep Department360) (Department360, error) {
+	return object360[Department360](ctx, a, http.MethodPost, a.endpoint(orgID, "/departments"), dep)
+}
+
+// UpdateDepartment changes the non-nil fields of the patch
+func (a Admin360) UpdateDepartment(orgID int, depID int, patch Department360Patch) (Department360, error) {
+	return a.UpdateDepartmentContext(context.Background(), orgID, depID, patch)
+}
+
+// UpdateDepartmentContext ...
+func (a Admin360) UpdateDepartmentContext(ctx context.Context, orgID int, depID int, patch Department360Patch) (Department360, error) {
+	return object360[Department360](ctx, a, http.MethodPatch, a.endpoint(orgID, "/departments/{id}", depID), patch)
+}
+
+// DeleteDepartment ...
+func (a Admin360) DeleteDepartment(orgID int, depID int) error {
+	return a.DeleteDepartmentContext(context.Background(), orgID, depID)
+}
+
+// DeleteDepartmentContext ...
+func (a Admin360) DeleteDepartmentContext(ctx context.Context, orgID int, depID int) error {
+	return a.request(ctx, http.MethodDelete, a.endpoint(orgID, "/departments/{id}", depID), nil, nil, nil)
+}
+
+// Groups
+
+// ListGroups returns one page of groups, params are page and perPage
+func (a Admin360) ListGroups(orgID int, params Parameters) (Page[Group360], error) {
+	return a.ListGroupsContext(context.Background(), orgID, params)
+}
+
+// ListGroupsContext ...
+func (a Admin360) ListGroupsContext(ctx context.Context, orgID int, params Parameters) (Page[Group360], error) {
+	return list360[Group360](ctx, a, a.endpoint(orgID, "/groups"), "groups", params)
+}
+
+// AllGroups iterates over groups of all pages
+func (a Admin360) AllGroups(ctx context.Context, orgID int, params Parameters) iter.Seq2[Group360, error] {
+	return allItems(ctx, pages360[Group360](a, a.endpoint(orgID, "/groups"), "groups"), params)
+}
+
+// ListAllGroups fetches groups of all pages with up to workers concurrent calls
+func (a Admin360) ListAllGroups(ctx context.Context, orgID int, params Parameters, workers int) ([]Group360, error) {
+	return fetchItems(ctx, pages360[Group360](a, a.endpoint(orgID, "/groups"), "groups"), params, workers)
+}
+
+// GetGroup ...
+func (a Admin360) GetGroup(orgID int, groupID int) (Group360, error) {
+	return a.GetGroupContext(context.Background(), orgID, groupID)
+}
+
+// GetGroupContext ...
+func (a Admin360) GetGroupContext(ctx context.Context, orgID int, groupID int) (Group360, error) {
+	return object360[Group360](ctx, a, http.MethodGet, a.endpoint(orgID, "/groups/{id}", groupID), nil)
+}
+
+// CreateGroup ...
+func (a Admin360) CreateGroup(orgID int, group Group360) (Group360, error) {
+	return a.CreateGroupContext(context.Background(), orgID, group)
+}
+
+// CreateGroupContext ...
+func (a Admin360) CreateGroupContext(ctx context.Context, orgID int, group Group360) (Group360, error) {
+	return object360[Group360](ctx, a, http.MethodPost, a.endpoint(orgID, "/groups"), group)
+}
+
+// UpdateGroup changes the non-nil fields of the patch
+func (a Admin360) UpdateGroup(orgID int, groupID int, patch Group360Patch) (Group360, error) {
+	return a.UpdateGroupContext(context.Background(), orgID, groupID, patch)
+}
+
+// UpdateGroupContext ...
+func (a Admin360) UpdateGroupContext(ctx context.Context, orgID int, groupID int, patch Group360Patch) (Group360, error) {
+	return object360[Group360](ctx, a, http.MethodPatch, a.endpoint(orgID, "/groups/{id}", groupID), patch)
+}
+
+// DeleteGroup ...
+func (a Admin360) DeleteGroup(orgID int, groupID int) error {
+	return a.DeleteGroupContext(context.Background(), orgID, groupID)
+}
+
+// DeleteGroupContext ...
+func (a Admin360) DeleteGroupContext(ctx context.Context, orgID int, groupID int) error {
+	return a.request(ctx, http.MethodDelete, a.endpoint(orgID, "/groups/{id}", groupID), nil, nil, nil)
+}
+
+// Domains
+
+// ListDomains returns one page of domains, params are page and perPage
+func (a Admin360) ListDomains(orgID int, params Parameters) (Page[Domain360], error) {
+	return a.ListDomainsContext(context.Background(), orgID, params)
+}
+
+// ListDomainsContext ...
+func (a Admin360) ListDomainsContext(ctx context.Context, orgID int, params Parameters) (Page[Domain360], error) {
+	return list360[Domain360](ctx, a, a.endpoint(orgID, "/domains"), "domains", params)
+}
+
+// ListAllDomains fetches domains of all pages with up to workers concurrent calls
+func (a Admin360) ListAllDomains(ctx context.Context, orgID int, params Parameters, workers int) ([]Domain360, error) {
+	return fetchItems(ctx, pages360[Domain360](a, a.endpoint(orgID, "/domains"), "domains"), params, workers)
+}
+
+// AddDomain adds the domain to the organization,
+// a domain already added to another organization is returned as *ConflictError
+func (a Admin360) AddDomain(orgID int, domain string) (Domain360, error) {
+	return a.AddDomainContext(context.Background(), orgID, domain)
+}
+
+// AddDomainContext ...
+func (a Admin360) AddDomainContext(ctx context.Context, orgID int, domain string) (Domain360, error) {
+	added, err := object360[Domain360](ctx, a, http.MethodPost, a.endpoint(orgID, "/domains"), struct {
+		Name string `json:"name"`
+	}{domain})
+	if IsConflict(err) {
+		err = &ConflictError{Kind: "domain", Name: domain, Err: err}
+	}
+	return added, err
+}
+
+// DeleteDomain ...
+func (a Admin360) DeleteDomain(orgID int, domain string) error {
+	return a.DeleteDomainContext(context.Background(), orgID, domain)
+}
+
+// DeleteDomainContext ...
+func (a Admin360) DeleteDomainContext(ctx context.Context, orgID int, domain string) error {
+	return a.request(ctx, http.MethodDelete, a.endpoint(orgID, "/domains/{domain}", domain), nil, nil, nil)
+}
@@ -0,0 +1,205 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdmin360Users(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		got  []string
+		fail = true
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("X-Org-ID") != "" {
+			t.Errorf("need no X-Org-ID but got %q", r.Header.Get("X-Org-ID"))
+		}
+		got = append(got, r.Method+" "+r.URL.RequestURI())
+		if fail {
+			fail = false
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page == 0 {
+				page = 1
+			}
+			fmt.Fprintf(w, `{"users": [{"id": "%d", "nickname": "user%d"}], "page": %d, "pages": 3, "perPage": 1, "total": 3}`, page, page, page)
+		case http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			fmt.Fprintf(w, `{"id": "7", "position": %q}`, body)
+		default:
+			fmt.Fprint(w, `{"id": "7", "nickname": "new"}`)
+		}
+	}))
+	defer srv.Close()
+	a := NewAdmin360(srv.Client(),
+		WithBaseURL(srv.URL),
+		WithDefaultOrgID(42),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+
+	ctx := context.Background()
+	var nicknames []string
+	for u, err := range a.AllUsers(ctx, 0, Parameters{"perPage": []string{"1"}}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		nicknames = append(nicknames, u.Nickname)
+	}
+	if fmt.Sprint(nicknames) != "[user1 user2 user3]" {
+		t.Errorf("got users %v", nicknames)
+	}
+	if users, err := a.ListAllUsers(ctx, 42, nil, 2); err != nil || len(users) != 3 {
+		t.Errorf("list all: %v, '%v'", users, err)
+	}
+	if u, err := a.CreateUser(42, User360{Nickname: "new"}); err != nil || u.ID != "7" {
+		t.Errorf("create: %v, '%v'", u, err)
+	}
+	if u, err := a.UpdateUser(42, "7", User360Patch{Position: String(""), IsDismissed: Bool(false)}); err != nil || u.Position != `{"position":"","isDismissed":false}` {
+		t.Errorf("update: %v, '%v'", u, err)
+	}
+
+	need := []string{
+		"GET /directory/v1/org/42/users?perPage=1",
+		"GET /directory/v1/org/42/users?perPage=1",
+		"GET /directory/v1/org/42/users?page=2&perPage=1",
+		"GET /directory/v1/org/42/users?page=3&perPage=1",
+	}
+	if !reflect.DeepEqual(got[:4], need) {
+		t.Errorf("got requests %v", got[:4])
+	}
+	if last := got[len(got)-2:]; last[0] != "POST /directory/v1/org/42/users" || last[1] != "PATCH /directory/v1/org/42/users/7" {
+		t.Errorf("got requests %v", last)
+	}
+}
+
+func TestAdmin360GroupsAndDomains(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodDelete:
+			fmt.Fprint(w, `{"removed": true}`)
+		case r.URL.Path == "/directory/v1/org/1/groups/5":
+			fmt.Fprint(w, `{"id": 5, "name": "sales", "members": [{"type": "user", "id": "11"}], "adminIds": ["12"], "authorId": "12"}`)
+		case r.URL.Path == "/directory/v1/org/1/domains" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code": 6, "message": "domain occupied"}`)
+		default:
+			fmt.Fprint(w, `{"domains": [{"name": "example.com", "master": true, "verified": true}], "page": 1, "pages": 1, "perPage": 10, "total": 1}`)
+		}
+	}))
+	defer srv.Close()
+	a := NewAdmin360(srv.Client(), WithBaseURL(srv.URL))
+
+	group, err := a.GetGroup(1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dg := group.DirectoryGroup()
+	if dg.Members[0] != UserMember(11) || dg.Admins[0].ID != 12 || dg.Author.ID != 12 {
+		t.Errorf("got directory group %+v", dg)
+	}
+	if err := a.DeleteGroup(1, 5); err != nil {
+		t.Errorf("delete group: '%v'", err)
+	}
+	if page, err := a.ListDomains(1, nil); err != nil || len(page.Result) != 1 || !page.Result[0].Verified {
+		t.Errorf("list domains: %v, '%v'", page, err)
+	}
+	_, err = a.AddDomain(1, "taken.com")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Name != "taken.com" {
+		t.Errorf("need conflict error but got '%v'", err)
+	}
+	if err := a.DeleteDomain(1, "example.com"); err != nil {
+		t.Errorf("delete domain: '%v'", err)
+	}
+	need := "[GET /directory/v1/org/1/groups/5 DELETE /directory/v1/org/1/groups/5 GET /directory/v1/org/1/domains " +
+		"POST /directory/v1/org/1/domains DELETE /directory/v1/org/1/domains/example.com]"
+	if fmt.Sprint(got) != need {
+		t.Errorf("got requests %v", got)
+	}
+}
+
+func TestAdmin360Conversions(t *testing.T) {
+	user := DirectoryUser{
+		ID:           1130000000000001,
+		Nickname:     "ivan",
+		DepartmentID: 2,
+		Name:         &DirectoryUserName{First: "Ivan", Last: "Petrov"},
+		ExternalID:   "ext",
+		Groups:       []DirectoryUserGroup{{ID: 3}},
+		Contacts:     []DirectoryUserContact{{Type: ContactEmail, Value: "ivan@example.com"}},
+		Aliases:      []string{"vanya"},
+		IsAdmin:      true,
+	}
+	u360 := User360FromDirectory(user)
+	if u360.ID != "1130000000000001" || u360.ExternalID != "ext" || u360.Groups[0] != 3 {
+		t.Errorf("got 360 user %+v", u360)
+	}
+	if back := u360.DirectoryUser(); !reflect.DeepEqual(back, user) {
+		t.Errorf("got user %+v back", back)
+	}
+
+	var numeric struct {
+		User       DirectoryUser       `json:"user"`
+		Department DirectoryDepartment `json:"department"`
+	}
+	if err := json.Unmarshal([]byte(`{"user": {"id": 1, "external_id": 123456789}, "department": {"id": 2, "external_id": 9876543210}}`), &numeric); err != nil {
+		t.Fatal(err)
+	}
+	if u := User360FromDirectory(numeric.User); u.ExternalID != "123456789" || u.DirectoryUser().ExternalID != "123456789" {
+		t.Errorf("got numeric external id %q", u.ExternalID)
+	}
+	if d := Department360FromDirectory(numeric.Department); d.ExternalID != "9876543210" || d.DirectoryDepartment().ExternalID != "9876543210" {
+		t.Errorf("got numeric external id %q", d.ExternalID)
+	}
+	if id := externalID(json.Number("42")); id != "42" {
+		t.Errorf("got json.Number external id %q", id)
+	}
+
+	dep := DirectoryDepartment{ID: 2, Name: "Sales", Parent: DirectoryDepartmentParent{ID: 1}, Head: directoryID{ID: 7}, ExternalID: "ext"}
+	d360 := Department360FromDirectory(dep)
+	if d360.ParentID != 1 || d360.HeadID != "7" {
+		t.Errorf("got 360 department %+v", d360)
+	}
+	if back := d360.DirectoryDepartment(); !reflect.DeepEqual(back, dep) {
+		t.Errorf("got department %+v back", back)
+	}
+
+	group := DirectoryGroup{
+		ID:      5,
+		Name:    "sales",
+		Members: []DirectoryGroupMember{UserMember(11), DepartmentMember(2)},
+		Admins:  []DirectoryGroupUser{{ID: 12}},
+		Author:  DirectoryGroupUser{ID: 12},
+	}
+	g360 := Group360FromDirectory(group)
+	if g360.Members[1] != (Group360Member{Type: MemberDepartment, ID: "2"}) || g360.AdminIDs[0] != "12" {
+		t.Errorf("got 360 group %+v", g360)
+	}
+	if back := g360.DirectoryGroup(); !reflect.DeepEqual(back, group) {
+		t.Errorf("got group %+v back", back)
+	}
+
+	j, _ := json.Marshal(g360)
+	if string(j) != `{"id":5,"name":"sales","members":[{"type":"user","id":"11"},{"type":"department","id":"2"}],"adminIds":["12"],"authorId":"12"}` {
+		t.Errorf("got json %s", j)
+	}
+}
@@ -0,0 +1,124 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// DirectoryAlias is an additional email name of a user, a department or a group
+type DirectoryAlias struct {
+	Name string `json:"name"`
+}
+
+// AddUserAlias adds the alias to the user, a duplicate alias is returned as *ConflictError
+func (d Directory) AddUserAlias(orgID, userID int, alias string) (DirectoryAlias, error) {
+	return d.AddUserAliasContext(context.Background(), orgID, userID, alias)
+}
+
+// AddUserAliasContext ...
+func (d Directory) AddUserAliasContext(ctx context.Context, orgID, userID int, alias string) (DirectoryAlias, error) {
+	return addAlias(ctx, d, d.endpoint("/users/{id}/aliases/", userID), orgID, alias)
+}
+
+// ListUserAliases ...
+func (d Directory) ListUserAliases(orgID, userID int) ([]string, error) {
+	return d.ListUserAliasesContext(context.Background(), orgID, userID)
+}
+
+// ListUserAliasesContext ...
+func (d Directory) ListUserAliasesContext(ctx context.Context, orgID, userID int) ([]string, error) {
+	user, err := d.GetUserContext(ctx, orgID, userID, Parameters{"fields": []string{"id", "aliases"}})
+	return user.Aliases, err
+}
+
+// DeleteUserAlias ...
+func (d Directory) DeleteUserAlias(orgID, userID int, alias string) error {
+	return d.DeleteUserAliasContext(context.Background(), orgID, userID, alias)
+}
+
+// DeleteUserAliasContext ...
+func (d Directory) DeleteUserAliasContext(ctx context.Context, orgID, userID int, alias string) error {
+	return d.delete(ctx, d.endpoint("/users/{id}/aliases/{alias}/", userID, alias), nil, orgID)
+}
+
+// AddDepartmentAlias adds the alias to the department, a duplicate alias is returned as *ConflictError
+func (d Directory) AddDepartmentAlias(orgID, depID int, alias string) (DirectoryAlias, error) {
+	return d.AddDepartmentAliasContext(context.Background(), orgID, depID, alias)
+}
+
+// AddDepartmentAliasContext ...
+func (d Directory) AddDepartmentAliasContext(ctx context.Context, orgID, depID int, alias string) (DirectoryAlias, error) {
+	return addAlias(ctx, d, d.endpoint("/departments/{id}/aliases/", depID), orgID, alias)
+}
+
+// ListDepartmentAliases ...
+func (d Directory) ListDepartmentAliases(orgID, depID int) ([]string, error) {
+	return d.ListDepartmentAliasesContext(context.Background(), orgID, depID)
+}
+
+// ListDepartmentAliasesContext ...
+func (d Directory) ListDepartmentAliasesContext(ctx context.Context, orgID, depID int) ([]string, error) {
+	department, err := d.GetDepartmentContext(ctx, orgID, depID, Parameters{"fields": []string{"id", "aliases"}})
+	return department.Aliases, err
+}
+
+// DeleteDepartmentAlias ...
+func (d Directory) DeleteDepartmentAlias(orgID, depID int, alias string) error {
+	return d.DeleteDepartmentAliasContext(context.Background(), orgID, depID, alias)
+}
+
+// DeleteDepartmentAliasContext ...
+func (d Directory) DeleteDepartmentAliasContext(ctx context.Context, orgID, depID int, alias string) error {
+	return d.delete(ctx, d.endpoint("/departments/{id}/aliases/{alias}/", depID, alias), nil, orgID)
+}
+
+// AddGroupAlias adds the alias to the group, a duplicate alias is returned as *ConflictError
+func (d Directory) AddGroupAlias(orgID, groupID int, alias string) (DirectoryAlias, error) {
+	return d.AddGroupAliasContext(context.Background(), orgID, groupID, alias)
+}
+
+// AddGroupAliasContext ...
+func (d Directory) AddGroupAliasContext(ctx context.Context, orgID, groupID int, alias string) (DirectoryAlias, error) {
+	return addAlias(ctx, d, d.endpoint("/groups/{id}/aliases/", groupID), orgID, alias)
+}
+
+// ListGroupAliases ...
+func (d Directory) ListGroupAliases(orgID, groupID int) ([]string, error) {
+	return d.ListGroupAliasesContext(context.Background(), orgID, groupID)
+}
+
+// ListGroupAliasesContext ...
+func (d Directory) ListGroupAliasesContext(ctx context.Context, orgID, groupID int) ([]string, error) {
+	group, err := d.GetGroupContext(ctx, orgID, groupID, Parameters{"fields": []string{"id", "aliases"}})
+	return group.Aliases, err
+}
+
+// DeleteGroupAlias ...
+func (d Directory) DeleteGroupAlias(orgID, groupID int, alias string) error {
+	return d.DeleteGroupAliasContext(context.Background(), orgID, groupID, alias)
+}
+
+// DeleteGroupAliasContext ...
+func (d Directory) DeleteGroupAliasContext(ctx context.Context, orgID, groupID int, alias string) error {
+	return d.delete(ctx, d.endpoint("/groups/{id}/aliases/{alias}/", groupID, alias), nil, orgID)
+}
+
+func addAlias(ctx context.Context, d Directory, ep endpoint, orgID int, alias string) (DirectoryAlias, error) {
+	created := DirectoryAlias{Name: alias}
+	j, err := json.Marshal(created)
+	if err != nil {
+		return created, err
+	}
+	err = d.post(
+		ctx,
+		ep,
+		nil,
+		orgID,
+		j,
+		&created,
+	)
+	if IsConflict(err) {
+		err = &ConflictError{Kind: "alias", Name: alias, Err: err}
+	}
+	return created, err
+}
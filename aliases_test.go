@@ -0,0 +1,51 @@
+package go_yapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"id": 2, "aliases": ["sales", "shop"]}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/groups/2/aliases/":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code": "alias_already_exists", "message": "Alias already exists"}`)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"name": "sales"}`)
+		}
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if alias, err := d.AddUserAlias(1, 2, "sales"); err != nil || alias.Name != "sales" {
+		t.Errorf("add user alias: %v, '%v'", alias, err)
+	}
+	if aliases, err := d.ListDepartmentAliases(1, 2); err != nil || len(aliases) != 2 {
+		t.Errorf("list department aliases: %v, '%v'", aliases, err)
+	}
+	if err := d.DeleteGroupAlias(1, 2, "sales"); err != nil {
+		t.Errorf("delete group alias: '%v'", err)
+	}
+
+	_, err := d.AddGroupAlias(1, 2, "sales")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Name != "sales" || !IsCode(err, "alias_already_exists") {
+		t.Errorf("need conflict error but got '%v'", err)
+	}
+
+	need := "[POST /users/2/aliases/ GET /departments/2/ DELETE /groups/2/aliases/sales/ POST /groups/2/aliases/]"
+	if fmt.Sprint(got) != need {
+		t.Errorf("got requests %v", got)
+	}
+}
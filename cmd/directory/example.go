@@ -8,6 +8,7 @@ import (
 	yapi "go-yapi"
 	"golang.org/x/oauth2"
 	"log"
+	"log/slog"
 	"math/rand"
 	"os"
 	"time"
@@ -73,9 +74,8 @@ func main() {
 
 	client := conf.Client(ctx, tok)
 
-	directory := yapi.NewDirectory(client)
-
-	yapi.Debug = true
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	directory := yapi.NewDirectory(client, yapi.WithLogger(logger))
 
 	newUser := yapi.DirectoryUser{
 		DepartmentID: 1,
@@ -93,11 +93,11 @@ func main() {
 	}
 	pretty("Create user", newUser)
 
-	err = directory.AddAliasUser(orgID, newUser.ID, "test-api")
+	alias, err := directory.AddUserAlias(orgID, newUser.ID, "test-api")
 	if err != nil {
 		log.Print("Add alias user ", err)
 	}
-	pretty("Add alias", `{"name":"test-api"}`)
+	pretty("Add alias", alias)
 
 	//orgs, err := directory.GetOrganizations(yapi.DirectoryOrganizationAllParameters)
 	//if err != nil {
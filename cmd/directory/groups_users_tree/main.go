@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"expvar"
 	"flag"
 	"fmt"
 	yapi "go-yapi"
@@ -12,9 +13,11 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"sort"
 	"strconv"
 	"sync"
+	"syscall"
 	"time"
 )
 
@@ -31,7 +34,8 @@ func main() {
 	flag.StringVar(&tokFile, "f", ".token", "Token file")
 	flag.Parse()
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	conf := yapi.NewOauth2Config(clientID, clientSecret, nil)
 
@@ -77,13 +81,38 @@ func main() {
 	}
 
 	client := conf.Client(ctx, tok)
+	metrics := yapi.NewMetrics()
+	metrics.Publish("yapi")
+	directory := yapi.NewDirectory(client,
+		yapi.WithLimiter(yapi.NewLimiter(10, 5, 4)),
+		yapi.WithMetrics(metrics),
+	)
 
 	if startWeb {
 		fmt.Println("Start web server on port", webPort)
-		log.Fatal(http.ListenAndServe(":"+webPort, handler(yapi.NewDirectory(client), orgID)))
+		mux := http.NewServeMux()
+		mux.Handle("/", handler(ctx, directory, orgID))
+		mux.Handle("/metrics", metrics)
+		mux.Handle("/debug/vars", expvar.Handler())
+		srv := &http.Server{Addr: ":" + webPort, Handler: mux}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(shutdownCtx); err != nil {
+				log.Print(err)
+			}
+		}()
+		if err = srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+		// ListenAndServe returns as soon as Shutdown starts, wait for the drain
+		<-done
 	} else {
 		buf := &bytes.Buffer{}
-		err = printTable(yapi.NewDirectory(client), orgID, buf)
+		err = printTable(ctx, directory, orgID, buf)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -103,9 +132,9 @@ type storage struct {
 	sync.RWMutex
 }
 
-func (s *storage) update(directory *yapi.Directory, orgID int) error {
+func (s *storage) update(ctx context.Context, directory *yapi.Directory, orgID int) error {
 	buf := &bytes.Buffer{}
-	err := printTable(directory, orgID, buf)
+	err := printTable(ctx, directory, orgID, buf)
 	if err != nil {
 		return err
 	}
@@ -115,17 +144,22 @@ func (s *storage) update(directory *yapi.Directory, orgID int) error {
 	return nil
 }
 
-func handler(directory *yapi.Directory, orgID int) http.HandlerFunc {
+func handler(ctx context.Context, directory *yapi.Directory, orgID int) http.HandlerFunc {
 	go func(directory *yapi.Directory) {
 		for {
 			log.Print("start update storage")
-			err := stor.update(directory, orgID)
+			err := stor.update(ctx, directory, orgID)
 			if err != nil {
 				log.Print(err)
-				continue
+			} else {
+				log.Print("storage updated, throttled ", directory.ThrottleStats().Wait)
+			}
+			select {
+			case <-ctx.Done():
+				log.Print("stop update storage")
+				return
+			case <-time.After(time.Minute * 10):
 			}
-			log.Print("storage updated")
-			time.Sleep(time.Minute * 10)
 		}
 	}(directory)
 
@@ -143,19 +177,18 @@ func (e SortByEmail) Len() int           { return len(e) }
 func (e SortByEmail) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
 func (e SortByEmail) Less(i, j int) bool { return e[i].Email < e[j].Email }
 
-func printTable(directory *yapi.Directory, orgID int, w io.Writer) error {
-	orgs, err := directory.GetOrganizations(nil)
+func printTable(ctx context.Context, directory *yapi.Directory, orgID int, w io.Writer) error {
+	orgs, err := directory.GetOrganizationsContext(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("get organizations %w", err)
 	}
 
 	if orgID != 0 {
-		for n := range orgs.Result {
-			if orgs.Result[n].ID == orgID {
-				orgs.Result = orgs.Result[n : n+1]
-				break
-			}
+		org, ok := orgs.ByID(orgID)
+		if !ok {
+			return fmt.Errorf("organization %d not found", orgID)
 		}
+		orgs.Result = []yapi.DirectoryOrganization{org}
 	}
 
 	for n := range orgs.Result {
@@ -167,22 +200,25 @@ func printTable(directory *yapi.Directory, orgID int, w io.Writer) error {
 		if err != nil {
 			return err
 		}
-		groups, err := directory.GetGroups(orgs.Result[n].ID, yapi.Parameters{"fields": []string{"id", "name", "email"}, "per_page": []string{"1000"}})
-		if err != nil {
-			return fmt.Errorf("get groups %w", err)
+		var groups []yapi.DirectoryGroup
+		for group, err := range directory.AllGroups(ctx, orgs.Result[n].ID, yapi.ListGroupsOptions{Fields: []string{"id", "name", "email"}, PerPage: 1000}) {
+			if err != nil {
+				return fmt.Errorf("get groups %w", err)
+			}
+			groups = append(groups, group)
 		}
 
-		for i := range groups.Result {
+		for i := range groups {
 			if i == 0 {
 				_, err = fmt.Fprintln(w, "├───────────────────────────────────────────────────────────────────┤")
 				if err != nil {
 					return err
 				}
 			}
-			if groups.Result[i].Email == "" {
+			if groups[i].Email == "" {
 				continue
 			}
-			_, err = fmt.Fprintf(w, "│ %-65s │\n", groups.Result[i].Email+" ("+groups.Result[i].Name+")")
+			_, err = fmt.Fprintf(w, "│ %-65s │\n", groups[i].Email+" ("+groups[i].Name+")")
 			if err != nil {
 				return err
 			}
@@ -191,20 +227,23 @@ func printTable(directory *yapi.Directory, orgID int, w io.Writer) error {
 				return err
 			}
 
-			users, err := directory.GetUsers(orgs.Result[n].ID, yapi.Parameters{"fields": []string{"name", "email"}, "group_id": []string{strconv.Itoa(groups.Result[i].ID)}, "per_page": []string{"1000"}})
+			var users []yapi.DirectoryUser
+			err = directory.EachUser(ctx, orgs.Result[n].ID, yapi.ListUsersOptions{Fields: []string{"name", "email"}, GroupID: groups[i].ID, PerPage: 1000}, func(u yapi.DirectoryUser) error {
+				users = append(users, u)
+				return nil
+			})
 			if err != nil {
 				return fmt.Errorf("get users %w", err)
-			} else {
-				sort.Sort(SortByEmail(users.Result))
-				for _, u := range users.Result {
-					_, err = fmt.Fprintf(w, "│ %-29s │ %-15s │ %-15s │\n", u.Email, u.Name.First, u.Name.Last)
-					if err != nil {
-						return err
-					}
+			}
+			sort.Sort(SortByEmail(users))
+			for _, u := range users {
+				_, err = fmt.Fprintf(w, "│ %-29s │ %-15s │ %-15s │\n", u.Email, u.Name.First, u.Name.Last)
+				if err != nil {
+					return err
 				}
 			}
 
-			if i != len(groups.Result)-1 {
+			if i != len(groups)-1 {
 				_, err = fmt.Fprintln(w, "├───────────────────────────────┴─────────────────┴─────────────────┤")
 				if err != nil {
 					return err
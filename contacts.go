@@ -0,0 +1,171 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+)
+
+// ContactType is the kind of a user contact
+type ContactType string
+
+const (
+	ContactEmail          ContactType = "email"
+	ContactPhone          ContactType = "phone"
+	ContactPhoneExtension ContactType = "phone_extension"
+	ContactSite           ContactType = "site"
+	ContactSkype          ContactType = "skype"
+	ContactICQ            ContactType = "icq"
+	ContactJabber         ContactType = "jabber"
+	ContactTwitter        ContactType = "twitter"
+	ContactFacebook       ContactType = "facebook"
+	ContactStaff          ContactType = "staff"
+	ContactOther          ContactType = "other"
+)
+
+// ErrInvalidContact is wrapped by errors of DirectoryUserContact.Validate
+var ErrInvalidContact = errors.New("invalid contact")
+
+var (
+	phoneRe     = regexp.MustCompile(`^\+?[0-9][0-9 ()\-]*[0-9]$`)
+	phoneDigits = regexp.MustCompile(`[0-9]`)
+	extensionRe = regexp.MustCompile(`^[0-9]{1,10}$`)
+)
+
+// Validate checks the value of phone, phone extension and email contacts
+func (c DirectoryUserContact) Validate() error {
+	if c.Type == "" || c.Value == "" {
+		return fmt.Errorf("%w: empty type or value", ErrInvalidContact)
+	}
+	switch c.Type {
+	case ContactPhone:
+		n := len(phoneDigits.FindAllString(c.Value, -1))
+		if !phoneRe.MatchString(c.Value) || n < 5 || n > 15 {
+			return fmt.Errorf("%w: phone %q", ErrInvalidContact, c.Value)
+		}
+	case ContactPhoneExtension:
+		if !extensionRe.MatchString(c.Value) {
+			return fmt.Errorf("%w: phone extension %q", ErrInvalidContact, c.Value)
+		}
+	case ContactEmail:
+		a, err := mail.ParseAddress(c.Value)
+		if err != nil || a.Address != c.Value {
+			return fmt.Errorf("%w: email %q", ErrInvalidContact, c.Value)
+		}
+	}
+	return nil
+}
+
+// ListUserContacts returns all contacts of the user including synthetic ones
+func (d Directory) ListUserContacts(orgID, userID int) ([]DirectoryUserContact, error) {
+	return d.ListUserContactsContext(context.Background(), orgID, userID)
+}
+
+// ListUserContactsContext ...
+func (d Directory) ListUserContactsContext(ctx context.Context, orgID, userID int) ([]DirectoryUserContact, error) {
+	user, err := d.GetUserContext(ctx, orgID, userID, Parameters{"fields": []string{"id", "contacts"}})
+	return user.Contacts, err
+}
+
+// SetUserContacts replaces the contacts of the user, synthetic contacts are kept by the API.
+// Every contact is validated before the call.
+func (d Directory) SetUserContacts(orgID, userID int, contacts []DirectoryUserContact) (DirectoryUser, error) {
+	return d.SetUserContactsContext(context.Background(), orgID, userID, contacts)
+}
+
+// SetUserContactsContext ...
+func (d Directory) SetUserContactsContext(ctx context.Context, orgID, userID int, contacts []DirectoryUserContact) (DirectoryUser, error) {
+	for _, c := range contacts {
+		if c.Synthetic {
+			continue
+		}
+		if err := c.Validate(); err != nil {
+			return DirectoryUser{}, err
+		}
+	}
+	return d.putUserContacts(ctx, orgID, userID, contacts)
+}
+
+// putUserContacts replaces the contacts without validation,
+// contacts read back from the API are sent as they are even if they are not valid now
+func (d Directory) putUserContacts(ctx context.Context, orgID, userID int, contacts []DirectoryUserContact) (DirectoryUser, error) {
+	var user DirectoryUser
+	send := make([]DirectoryUserContact, 0, len(contacts))
+	for _, c := range contacts {
+		if c.Synthetic {
+			continue
+		}
+		send = append(send, DirectoryUserContact{Type: c.Type, Value: c.Value, Main: c.Main})
+	}
+	j, err := json.Marshal(send)
+	if err != nil {
+		return user, err
+	}
+	err = d.request(
+		ctx,
+		http.MethodPut,
+		d.endpoint("/users/{id}/contacts/", userID),
+		nil,
+		orgID,
+		http.StatusOK,
+		j,
+		&user,
+	)
+	return user, err
+}
+
+// AddUserContact adds the contact to the existing ones, only the new contact is validated
+func (d Directory) AddUserContact(orgID, userID int, contact DirectoryUserContact) (DirectoryUser, error) {
+	return d.AddUserContactContext(context.Background(), orgID, userID, contact)
+}
+
+// AddUserContactContext ...
+func (d Directory) AddUserContactContext(ctx context.Context, orgID, userID int, contact DirectoryUserContact) (DirectoryUser, error) {
+	if err := contact.Validate(); err != nil {
+		return DirectoryUser{}, err
+	}
+	contacts, err := d.ListUserContactsContext(ctx, orgID, userID)
+	if err != nil {
+		return DirectoryUser{}, err
+	}
+	return d.putUserContacts(ctx, orgID, userID, append(contacts, contact))
+}
+
+// RemoveUserContact removes the contact with the type and value,
+// a *NotFoundError is returned if the user has no such contact
+func (d Directory) RemoveUserContact(orgID, userID int, contactType ContactType, value string) (DirectoryUser, error) {
+	return d.RemoveUserContactContext(context.Background(), orgID, userID, contactType, value)
+}
+
+// RemoveUserContactContext ...
+func (d Directory) RemoveUserContactContext(ctx context.Context, orgID, userID int, contactType ContactType, value string) (DirectoryUser, error) {
+	contacts, err := d.ListUserContactsContext(ctx, orgID, userID)
+	if err != nil {
+		return DirectoryUser{}, err
+	}
+	keep := make([]DirectoryUserContact, 0, len(contacts))
+	for _, c := range contacts {
+		if c.Type == contactType && c.Value == value && !c.Synthetic {
+			continue
+		}
+		keep = append(keep, c)
+	}
+	if len(keep) == len(contacts) {
+		return DirectoryUser{}, &NotFoundError{Kind: string(contactType) + " contact", Name: value}
+	}
+	return d.putUserContacts(ctx, orgID, userID, keep)
+}
+
+// DeleteUserContacts removes all contacts of the user except synthetic ones
+func (d Directory) DeleteUserContacts(orgID, userID int) error {
+	return d.DeleteUserContactsContext(context.Background(), orgID, userID)
+}
+
+// DeleteUserContactsContext ...
+func (d Directory) DeleteUserContactsContext(ctx context.Context, orgID, userID int) error {
+	return d.delete(ctx, d.endpoint("/users/{id}/contacts/", userID), nil, orgID)
+}
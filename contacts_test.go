@@ -0,0 +1,75 @@
+package go_yapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContactValidate(t *testing.T) {
+	do := func(c DirectoryUserContact, valid bool) {
+		if err := c.Validate(); (err == nil) != valid {
+			t.Errorf("%s %q validated as '%v'", c.Type, c.Value, err)
+		} else if err != nil && !errors.Is(err, ErrInvalidContact) {
+			t.Errorf("error '%v' is not ErrInvalidContact", err)
+		}
+	}
+
+	do(DirectoryUserContact{Type: ContactPhone, Value: "+7 (495) 739-70-00"}, true)
+	do(DirectoryUserContact{Type: ContactPhone, Value: "84957397000"}, true)
+	do(DirectoryUserContact{Type: ContactPhone, Value: "call me"}, false)
+	do(DirectoryUserContact{Type: ContactPhone, Value: "+7 12"}, false)
+	do(DirectoryUserContact{Type: ContactPhoneExtension, Value: "1234"}, true)
+	do(DirectoryUserContact{Type: ContactEmail, Value: "ivan@example.com"}, true)
+	do(DirectoryUserContact{Type: ContactEmail, Value: "Ivan <ivan@example.com>"}, false)
+	do(DirectoryUserContact{Type: ContactEmail, Value: "ivan"}, false)
+	do(DirectoryUserContact{Type: ContactSkype, Value: "ivan.skype"}, true)
+	do(DirectoryUserContact{Type: ContactSkype}, false)
+}
+
+func TestUserContacts(t *testing.T) {
+	var put string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id": 3, "contacts": [
+				{"type": "email", "value": "ivan@example.com", "synthetic": true},
+				{"type": "phone", "value": "+74957397000", "main": true},
+				{"type": "phone", "value": "ext. 12"}
+			]}`)
+		case http.MethodPut:
+			b, _ := io.ReadAll(r.Body)
+			put = string(b)
+			fmt.Fprint(w, `{"id": 3}`)
+		}
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if _, err := d.AddUserContact(1, 3, DirectoryUserContact{Type: ContactSkype, Value: "ivan"}); err != nil {
+		t.Fatal(err)
+	}
+	if put != `[{"value":"+74957397000","type":"phone","main":true},{"value":"ext. 12","type":"phone"},{"value":"ivan","type":"skype"}]` {
+		t.Errorf("add sent %s", put)
+	}
+
+	if _, err := d.RemoveUserContact(1, 3, ContactPhone, "+74957397000"); err != nil {
+		t.Fatal(err)
+	}
+	if put != `[{"value":"ext. 12","type":"phone"}]` {
+		t.Errorf("remove sent %s", put)
+	}
+
+	if _, err := d.RemoveUserContact(1, 3, ContactEmail, "ivan@example.com"); !IsNotFound(err) {
+		t.Errorf("synthetic contact must not be removed, got '%v'", err)
+	}
+	if _, err := d.AddUserContact(1, 3, DirectoryUserContact{Type: ContactPhone, Value: "none"}); !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("need invalid contact error but got '%v'", err)
+	}
+	if _, err := d.SetUserContacts(1, 3, []DirectoryUserContact{{Type: ContactPhone, Value: "ext. 12"}}); !errors.Is(err, ErrInvalidContact) {
+		t.Errorf("set must validate the given contacts, got '%v'", err)
+	}
+}
@@ -3,9 +3,15 @@ package go_yapi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -15,12 +21,203 @@ const (
 	directoryURL = directoryAPI + VersionAPI
 )
 
+// noOrgID is passed by calls which are not scoped to an organization, the X-Org-ID header is not sent
+const noOrgID = -1
+
 type Directory struct {
-	client *http.Client
+	client    *http.Client
+	baseURL   string
+	userAgent string
+	orgID     int
+	retry     RetryPolicy
+	limiter   *Limiter
+	logger    *slog.Logger
+	tracer    Tracer
+	query     QueryEncoder
+
+	interceptors []Interceptor
+}
+
+// DirectoryOption configures a Directory in NewDirectory
+type DirectoryOption func(*Directory)
+
+// WithBaseURL sends calls to baseURL instead of https://api.directory.yandex.net/v6,
+// e.g. to a proxy or a test server
+func WithBaseURL(baseURL string) DirectoryOption {
+	return func(d *Directory) {
+		d.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every call
+func WithUserAgent(userAgent string) DirectoryOption {
+	return func(d *Directory) {
+		d.userAgent = userAgent
+	}
+}
+
+// WithDefaultOrgID is used by methods called with zero orgID
+func WithDefaultOrgID(orgID int) DirectoryOption {
+	return func(d *Directory) {
+		d.orgID = orgID
+	}
+}
+
+// WithHTTPClient replaces the client given to NewDirectory
+func WithHTTPClient(client *http.Client) DirectoryOption {
+	return func(d *Directory) {
+		d.client = client
+	}
+}
+
+// WithQueryEncoder sets how query parameters are encoded, e.g. which keys are repeated
+func WithQueryEncoder(encoder QueryEncoder) DirectoryOption {
+	return func(d *Directory) {
+		d.query = encoder
+	}
+}
+
+// WithRetryPolicy enables retries of failed calls, see RetryPolicy
+func WithRetryPolicy(policy RetryPolicy) DirectoryOption {
+	return func(d *Directory) {
+		d.retry = policy
+	}
+}
+
+// WithLimiter throttles all calls of the Directory with the limiter, see NewLimiter
+func WithLimiter(limiter *Limiter) DirectoryOption {
+	return func(d *Directory) {
+		d.limiter = limiter
+	}
+}
+
+func NewDirectory(client *http.Client, opts ...DirectoryOption) *Directory {
+	d := &Directory{client: client, baseURL: directoryURL}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.client == nil {
+		d.client = http.DefaultClient
+	}
+	return d
+}
+
+// BaseURL returns the URL all endpoints are built from
+func (d Directory) BaseURL() string {
+	return d.baseURL
+}
+
+// endpoint is an API URL together with the template it is built from,
+// the template names the endpoint in metrics and traces
+type endpoint struct {
+	template string
+	url      string
+}
+
+// endpoint fills {placeholders} of the template with args in order
+func (d Directory) endpoint(template string, args ...interface{}) endpoint {
+	return endpoint{template: template, url: d.baseURL + expandTemplate(template, args...)}
+}
+
+func expandTemplate(template string, args ...interface{}) string {
+	path := &strings.Builder{}
+	rest := template
+	for _, arg := range args {
+		i := strings.IndexByte(rest, '{')
+		j := strings.IndexByte(rest, '}')
+		if i < 0 || j < i {
+			break
+		}
+		path.WriteString(rest[:i])
+		path.WriteString(url.PathEscape(fmt.Sprint(arg)))
+		rest = rest[j+1:]
+	}
+	path.WriteString(rest)
+	return path.String()
+}
+
+func (d Directory) header(orgID int) map[string]string {
+	header := headerOrgID(orgID)
+	if d.userAgent != "" {
+		header["User-Agent"] = d.userAgent
+	}
+	return header
+}
+
+// ThrottleStats reports how long calls waited for the limiter
+func (d Directory) ThrottleStats() LimiterStats {
+	return d.limiter.Stats()
+}
+
+func (d Directory) get(ctx context.Context, ep endpoint, params Parameters, orgID int, v interface{}) error {
+	return d.request(ctx, http.MethodGet, ep, params, orgID, http.StatusOK, nil, v)
 }
 
-func NewDirectory(client *http.Client) *Directory {
-	return &Directory{client: client}
+func (d Directory) post(ctx context.Context, ep endpoint, params Parameters, orgID int, body []byte, v interface{}) error {
+	return d.request(ctx, http.MethodPost, ep, params, orgID, http.StatusCreated, body, v)
+}
+
+func (d Directory) patch(ctx context.Context, ep endpoint, params Parameters, orgID int, body []byte, v interface{}) error {
+	return d.request(ctx, http.MethodPatch, ep, params, orgID, http.StatusOK, body, v)
+}
+
+func (d Directory) delete(ctx context.Context, ep endpoint, params Parameters, orgID int) error {
+	return d.request(ctx, http.MethodDelete, ep, params, orgID, http.StatusNoContent, nil, nil)
+}
+
+// request sends the call through the chain:
+// tracer, retry policy, interceptors, logger, limiter and the HTTP client.
+// The body is kept as bytes so that every attempt gets a fresh reader.
+func (d Directory) request(ctx context.Context, method string, ep endpoint, params Parameters, orgID int, expectedStatus int, body []byte, v interface{}) error {
+	if orgID == 0 {
+		orgID = d.orgID
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := newRequestWithEncoder(ctx, d.query, method, ep.url, params, d.header(orgID), reader)
+	if err != nil {
+		return err
+	}
+	call := &Call{
+		Request:        req,
+		RequestBody:    body,
+		ExpectedStatus: expectedStatus,
+		Endpoint:       ep.template,
+		OrgID:          orgID,
+		Attempt:        1,
+	}
+	if err = d.roundTrip()(ctx, call); err != nil {
+		var e *APIError
+		if Debug && errors.As(err, &e) {
+			log.Print(redactBody(e.Body))
+		}
+		return err
+	}
+	return decodeBody(call.ResponseBody, v)
+}
+
+func (d Directory) roundTrip() RoundTrip {
+	logger := d.logger
+	if logger == nil && Debug {
+		logger = slog.Default()
+	}
+	interceptors := make([]Interceptor, 0, len(d.interceptors)+4)
+	if d.tracer != nil {
+		interceptors = append(interceptors, TracingInterceptor(d.tracer))
+	}
+	if d.retry.MaxAttempts > 1 {
+		interceptors = append(interceptors, RetryInterceptor(d.retry))
+	}
+	interceptors = append(interceptors, d.interceptors...)
+	if logger != nil {
+		interceptors = append(interceptors, LoggingInterceptor(logger))
+	}
+	if d.limiter != nil {
+		interceptors = append(interceptors, d.limiter.interceptor())
+	}
+	return chain(transport(d.client), interceptors...)
 }
 
 //     ____ ___
@@ -68,27 +265,15 @@ type DirectoryUserName struct {
 }
 
 type DirectoryUserContact struct {
-	Value     string `json:"value,omitempty"`
-	Type      string `json:"type,omitempty"`
-	Main      bool   `json:"main,omitempty"`
-	Alias     bool   `json:"alias,omitempty"`
-	Synthetic bool   `json:"synthetic,omitempty"`
-}
-
-type DirectoryUsers struct {
-	Page    int             `json:"page"`
-	Total   int             `json:"total"`
-	PerPage int             `json:"per_page"`
-	Result  []DirectoryUser `json:"result"`
-	Pages   int             `json:"pages"`
-	Links   struct {
-		Next  string `json:"next"`
-		Prev  string `json:"prev"`
-		Last  string `json:"last"`
-		First string `json:"first"`
-	} `json:"links"`
+	Value     string      `json:"value,omitempty"`
+	Type      ContactType `json:"type,omitempty"`
+	Main      bool        `json:"main,omitempty"`
+	Alias     bool        `json:"alias,omitempty"`
+	Synthetic bool        `json:"synthetic,omitempty"`
 }
 
+type DirectoryUsers = Page[DirectoryUser]
+
 var DirectoryUserAllParameters = Parameters{
 	"fields": []string{
 		"is_robot",
@@ -115,73 +300,79 @@ var DirectoryUserAllParameters = Parameters{
 
 // GetUsers ...
 func (d Directory) GetUsers(orgID int, params Parameters) (DirectoryUsers, error) {
-	var users DirectoryUsers
-	err := Get(
-		d.client,
-		directoryURL+"/users/",
-		params,
-		headerOrgID(orgID),
-		&users,
-	)
-	return users, err
+	return d.GetUsersContext(context.Background(), orgID, params)
+}
+
+// GetUsersContext ...
+func (d Directory) GetUsersContext(ctx context.Context, orgID int, params Parameters) (DirectoryUsers, error) {
+	return list[DirectoryUser](ctx, d, d.endpoint("/users/"), orgID, params)
 }
 
 // GetUser ...
 func (d Directory) GetUser(orgID, userID int, params Parameters) (DirectoryUser, error) {
-	var user DirectoryUser
-	err := Get(
-		d.client,
-		directoryURL+"/users/"+strconv.Itoa(userID)+"/",
-		params,
-		headerOrgID(orgID),
-		&user,
-	)
-	return user, err
+	return d.GetUserContext(context.Background(), orgID, userID, params)
+}
+
+// GetUserContext ...
+func (d Directory) GetUserContext(ctx context.Context, orgID, userID int, params Parameters) (DirectoryUser, error) {
+	return get[DirectoryUser](ctx, d, d.endpoint("/users/{id}/", userID), orgID, params)
 }
 
 func (d Directory) CreateUser(orgID int, user *DirectoryUser) error {
+	return d.CreateUserContext(context.Background(), orgID, user)
+}
+
+func (d Directory) CreateUserContext(ctx context.Context, orgID int, user *DirectoryUser) error {
 	j, err := json.Marshal(user)
 	if err != nil {
 		return err
 	}
 
-	return Post(
-		d.client,
-		directoryURL+"/users/",
+	return d.post(
+		ctx,
+		d.endpoint("/users/"),
 		nil,
-		headerOrgID(orgID),
-		bytes.NewReader(j),
+		orgID,
+		j,
 		&user,
 	)
 }
 
 func (d Directory) ModifyUser(orgID, userID int, user *DirectoryUser) error {
+	return d.ModifyUserContext(context.Background(), orgID, userID, user)
+}
+
+func (d Directory) ModifyUserContext(ctx context.Context, orgID, userID int, user *DirectoryUser) error {
 	j, err := json.Marshal(user)
 	if err != nil {
 		return err
 	}
 
-	err = Patch(
-		d.client,
-		directoryURL+"/users/"+strconv.Itoa(userID)+"/",
+	err = d.patch(
+		ctx,
+		d.endpoint("/users/{id}/", userID),
 		nil,
-		headerOrgID(orgID),
-		bytes.NewReader(j),
+		orgID,
+		j,
 		&user,
 	)
 
 	return err
 }
 
+// AddAliasUser ...
+//
+// Deprecated: use AddUserAlias which returns the created alias.
 func (d Directory) AddAliasUser(orgID, userID int, alias string) error {
-	return Post(
-		d.client,
-		directoryURL+"/users/"+strconv.Itoa(userID)+"/aliases/",
-		nil,
-		headerOrgID(orgID),
-		strings.NewReader(`{"name": `+jsonParam(alias)+`}`),
-		nil,
-	)
+	return d.AddAliasUserContext(context.Background(), orgID, userID, alias)
+}
+
+// AddAliasUserContext ...
+//
+// Deprecated: use AddUserAliasContext which returns the created alias.
+func (d Directory) AddAliasUserContext(ctx context.Context, orgID, userID int, alias string) error {
+	_, err := d.AddUserAliasContext(ctx, orgID, userID, alias)
+	return err
 }
 
 //    ________                              __                         __
@@ -204,6 +395,7 @@ type DirectoryDepartment struct {
 	Description  string                      `json:"description,omitempty"`
 	MembersCount int                         `json:"members_count,omitempty"`
 	Head         directoryID                 `json:"head,omitempty"`
+	Aliases      []string                    `json:"aliases,omitempty"`
 }
 
 type DirectoryDepartmentParent struct {
@@ -219,19 +411,7 @@ type DirectoryDepartmentParent struct {
 	MembersCount int         `json:"members_count,omitempty"`
 }
 
-type DirectoryDepartments struct {
-	Page    int                   `json:"page"`
-	Total   int                   `json:"total"`
-	PerPage int                   `json:"per_page"`
-	Result  []DirectoryDepartment `json:"result"`
-	Pages   int                   `json:"pages"`
-	Links   struct {
-		Next  string `json:"next"`
-		Prev  string `json:"prev"`
-		Last  string `json:"last"`
-		First string `json:"first"`
-	} `json:"links"`
-}
+type DirectoryDepartments = Page[DirectoryDepartment]
 
 var DirectoryDepartmentAllParameters = Parameters{
 	"fields": []string{
@@ -247,33 +427,28 @@ var DirectoryDepartmentAllParameters = Parameters{
 		"description",
 		"members_count",
 		"head",
+		"aliases",
 	},
 }
 
 // GetDepartments ...
 func (d Directory) GetDepartments(orgID int, params Parameters) (DirectoryDepartments, error) {
-	var departments DirectoryDepartments
-	err := Get(
-		d.client,
-		directoryURL+"/departments/",
-		params,
-		headerOrgID(orgID),
-		&departments,
-	)
-	return departments, err
+	return d.GetDepartmentsContext(context.Background(), orgID, params)
+}
+
+// GetDepartmentsContext ...
+func (d Directory) GetDepartmentsContext(ctx context.Context, orgID int, params Parameters) (DirectoryDepartments, error) {
+	return list[DirectoryDepartment](ctx, d, d.endpoint("/departments/"), orgID, params)
 }
 
 // GetDepartment ...
 func (d Directory) GetDepartment(orgID, depID int, params Parameters) (DirectoryDepartment, error) {
-	var department DirectoryDepartment
-	err := Get(
-		d.client,
-		directoryURL+"/departments/"+strconv.Itoa(depID)+"/",
-		params,
-		headerOrgID(orgID),
-		&department,
-	)
-	return department, err
+	return d.GetDepartmentContext(context.Background(), orgID, depID, params)
+}
+
+// GetDepartmentContext ...
+func (d Directory) GetDepartmentContext(ctx context.Context, orgID, depID int, params Parameters) (DirectoryDepartment, error) {
+	return get[DirectoryDepartment](ctx, d, d.endpoint("/departments/{id}/", depID), orgID, params)
 }
 
 type DirectoryNewDepartment struct {
@@ -286,17 +461,22 @@ type DirectoryNewDepartment struct {
 
 // CreateDepartment ...
 func (d Directory) CreateDepartment(orgID int, newDepartment DirectoryNewDepartment) (DirectoryDepartment, error) {
+	return d.CreateDepartmentContext(context.Background(), orgID, newDepartment)
+}
+
+// CreateDepartmentContext ...
+func (d Directory) CreateDepartmentContext(ctx context.Context, orgID int, newDepartment DirectoryNewDepartment) (DirectoryDepartment, error) {
 	var department DirectoryDepartment
 	j, err := json.Marshal(newDepartment)
 	if err != nil {
 		return department, err
 	}
-	err = Post(
-		d.client,
-		directoryURL+"/departments/",
+	err = d.post(
+		ctx,
+		d.endpoint("/departments/"),
 		nil,
-		headerOrgID(orgID),
-		bytes.NewReader(j),
+		orgID,
+		j,
 		&department,
 	)
 	return department, err
@@ -304,17 +484,22 @@ func (d Directory) CreateDepartment(orgID int, newDepartment DirectoryNewDepartm
 
 // ModifyDepartment ...
 func (d Directory) ModifyDepartment(orgID, depID int, newDepartment DirectoryNewDepartment) (DirectoryDepartment, error) {
+	return d.ModifyDepartmentContext(context.Background(), orgID, depID, newDepartment)
+}
+
+// ModifyDepartmentContext ...
+func (d Directory) ModifyDepartmentContext(ctx context.Context, orgID, depID int, newDepartment DirectoryNewDepartment) (DirectoryDepartment, error) {
 	var department DirectoryDepartment
 	j, err := json.Marshal(newDepartment)
 	if err != nil {
 		return department, err
 	}
-	err = Patch(
-		d.client,
-		directoryURL+"/departments/"+strconv.Itoa(depID)+"/",
+	err = d.patch(
+		ctx,
+		d.endpoint("/departments/{id}/", depID),
 		nil,
-		headerOrgID(orgID),
-		bytes.NewReader(j),
+		orgID,
+		j,
 		&department,
 	)
 	return department, err
@@ -322,11 +507,16 @@ func (d Directory) ModifyDepartment(orgID, depID int, newDepartment DirectoryNew
 
 // DeleteDepartment ...
 func (d Directory) DeleteDepartment(orgID, depID int) error {
-	return Delete(
-		d.client,
-		directoryURL+"/departments/"+strconv.Itoa(depID)+"/",
+	return d.DeleteDepartmentContext(context.Background(), orgID, depID)
+}
+
+// DeleteDepartmentContext ...
+func (d Directory) DeleteDepartmentContext(ctx context.Context, orgID, depID int) error {
+	return d.delete(
+		ctx,
+		d.endpoint("/departments/{id}/", depID),
 		nil,
-		headerOrgID(orgID),
+		orgID,
 	)
 }
 
@@ -338,22 +528,20 @@ func (d Directory) DeleteDepartment(orgID, depID int) error {
 //            \/                   |__|       \/
 
 type DirectoryGroup struct {
-	Name       string `json:"name,omitempty"`
-	Email      string `json:"email,omitempty"`
-	ExternalID string `json:"external_id,omitempty"`
-	ID         int    `json:"id,omitempty"`
-	Members    []struct {
-		Type   string      `json:"type"` // <user|group|department>
-		Object directoryID `json:"object"`
-	} `json:"members,omitempty"`
-	Label        string               `json:"label,omitempty"`
-	Created      string               `json:"created,omitempty"`
-	Type         string               `json:"type,omitempty"`
-	Admins       []DirectoryGroupUser `json:"admins,omitempty"`
-	Author       DirectoryGroupUser   `json:"author,omitempty"`
-	Description  string               `json:"description,omitempty"`
-	MembersCount int                  `json:"members_count,omitempty"`
-	MemberOf     []int                `json:"member_of,omitempty"`
+	Name         string                 `json:"name,omitempty"`
+	Email        string                 `json:"email,omitempty"`
+	ExternalID   string                 `json:"external_id,omitempty"`
+	ID           int                    `json:"id,omitempty"`
+	Members      []DirectoryGroupMember `json:"members,omitempty"`
+	Label        string                 `json:"label,omitempty"`
+	Created      string                 `json:"created,omitempty"`
+	Type         string                 `json:"type,omitempty"`
+	Admins       []DirectoryGroupUser   `json:"admins,omitempty"`
+	Author       DirectoryGroupUser     `json:"author,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	MembersCount int                    `json:"members_count,omitempty"`
+	MemberOf     []int                  `json:"member_of,omitempty"`
+	Aliases      []string               `json:"aliases,omitempty"`
 }
 
 type DirectoryGroupUser struct {
@@ -375,31 +563,7 @@ type DirectoryGroupUser struct {
 	About        string                 `json:"about,omitempty"`
 }
 
-type DirectoryGroups struct {
-	Page    int              `json:"page"`
-	Total   int              `json:"total"`
-	PerPage int              `json:"per_page"`
-	Result  []DirectoryGroup `json:"result"`
-	Pages   int              `json:"pages"`
-	Links   struct {
-		Next  string `json:"next"`
-		Prev  string `json:"prev"`
-		Last  string `json:"last"`
-		First string `json:"first"`
-	} `json:"links"`
-}
-
-type DirectoryGroupMember struct {
-	Type   string `json:"type"`
-	Object struct {
-		DepartmentID int               `json:"department_id"`
-		ID           int               `json:"id"`
-		Nickname     string            `json:"nickname"`
-		Email        string            `json:"email"`
-		Gender       string            `json:"gender"`
-		Name         DirectoryUserName `json:"name"`
-	} `json:"object"`
-}
+type DirectoryGroups = Page[DirectoryGroup]
 
 var DirectoryGroupAllParameters = Parameters{
 	"fields": []string{
@@ -416,46 +580,96 @@ var DirectoryGroupAllParameters = Parameters{
 		"description",
 		"members_count",
 		"member_of",
+		"aliases",
 	},
 }
 
 func (d Directory) GetGroups(orgID int, params Parameters) (DirectoryGroups, error) {
-	var groups DirectoryGroups
-	err := Get(
-		d.client,
-		directoryURL+"/groups/",
-		params,
-		headerOrgID(orgID),
-		&groups,
-	)
-	return groups, err
+	return d.GetGroupsContext(context.Background(), orgID, params)
+}
+
+func (d Directory) GetGroupsContext(ctx context.Context, orgID int, params Parameters) (DirectoryGroups, error) {
+	return list[DirectoryGroup](ctx, d, d.endpoint("/groups/"), orgID, params)
 }
 
 func (d Directory) GetGroup(orgID, groupID int, params Parameters) (DirectoryGroup, error) {
+	return d.GetGroupContext(context.Background(), orgID, groupID, params)
+}
+
+func (d Directory) GetGroupContext(ctx context.Context, orgID, groupID int, params Parameters) (DirectoryGroup, error) {
+	return get[DirectoryGroup](ctx, d, d.endpoint("/groups/{id}", groupID), orgID, params)
+}
+
+type DirectoryNewGroup struct {
+	Name        string                 `json:"name,omitempty"`
+	Label       string                 `json:"label,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Members     []DirectoryGroupMember `json:"members,omitempty"`
+	Admins      []DirectoryGroupAdmin  `json:"admins,omitempty"`
+	ExternalID  string                 `json:"external_id,omitempty"`
+}
+
+type DirectoryGroupAdmin directoryID
+
+// CreateGroup ...
+func (d Directory) CreateGroup(orgID int, newGroup DirectoryNewGroup) (DirectoryGroup, error) {
+	return d.CreateGroupContext(context.Background(), orgID, newGroup)
+}
+
+// CreateGroupContext ...
+func (d Directory) CreateGroupContext(ctx context.Context, orgID int, newGroup DirectoryNewGroup) (DirectoryGroup, error) {
 	var group DirectoryGroup
-	err := Get(
-		d.client,
-		directoryURL+"/groups/"+strconv.Itoa(groupID),
-		params,
-		headerOrgID(orgID),
+	j, err := json.Marshal(newGroup)
+	if err != nil {
+		return group, err
+	}
+	err = d.post(
+		ctx,
+		d.endpoint("/groups/"),
+		nil,
+		orgID,
+		j,
 		&group,
 	)
 	return group, err
 }
 
-// CreateGroup ToDo
-func (d Directory) CreateGroup(orgID int) error {
-	return errors.New("not finish")
+// ModifyGroup ...
+func (d Directory) ModifyGroup(orgID, groupID int, newGroup DirectoryNewGroup) (DirectoryGroup, error) {
+	return d.ModifyGroupContext(context.Background(), orgID, groupID, newGroup)
+}
+
+// ModifyGroupContext ...
+func (d Directory) ModifyGroupContext(ctx context.Context, orgID, groupID int, newGroup DirectoryNewGroup) (DirectoryGroup, error) {
+	var group DirectoryGroup
+	j, err := json.Marshal(newGroup)
+	if err != nil {
+		return group, err
+	}
+	err = d.patch(
+		ctx,
+		d.endpoint("/groups/{id}/", groupID),
+		nil,
+		orgID,
+		j,
+		&group,
+	)
+	return group, err
 }
 
-// ModifyGroup ToDo
-func (d Directory) ModifyGroup(orgID int) error {
-	return errors.New("not finish")
+// DeleteGroup ...
+func (d Directory) DeleteGroup(orgID, groupID int) error {
+	return d.DeleteGroupContext(context.Background(), orgID, groupID)
 }
 
-// DeleteGroup ToDo
-func (d Directory) DeleteGroup(orgID int) error {
-	return errors.New("not finish")
+// DeleteGroupContext ...
+func (d Directory) DeleteGroupContext(ctx context.Context, orgID, groupID int) error {
+	return d.delete(
+		ctx,
+		d.endpoint("/groups/{id}/", groupID),
+		nil,
+		orgID,
+	)
 }
 
 //    ________                        .__
@@ -494,12 +708,16 @@ var DirectoryDomainAllParameters = Parameters{
 }
 
 func (d Directory) GetDomains(orgID int, params Parameters) ([]DirectoryDomain, error) {
+	return d.GetDomainsContext(context.Background(), orgID, params)
+}
+
+func (d Directory) GetDomainsContext(ctx context.Context, orgID int, params Parameters) ([]DirectoryDomain, error) {
 	var domains []DirectoryDomain
-	err := Get(
-		d.client,
-		directoryURL+"/domains/",
+	err := d.get(
+		ctx,
+		d.endpoint("/domains/"),
 		params,
-		headerOrgID(orgID),
+		orgID,
 		&domains,
 	)
 	return domains, err
@@ -515,31 +733,78 @@ func (d Directory) GetDomains(orgID int, params Parameters) ([]DirectoryDomain,
 //
 
 type DirectoryOrganizations struct {
-	Links  interface{} `json:"links"`
-	Result []struct {
-		Revision int    `json:"revision,omitempty"`
-		ID       int    `json:"id,omitempty"`
-		Label    string `json:"label,omitempty"`
-		Domains  struct {
-			Display string   `json:"display"`
-			Master  string   `json:"master"`
-			All     []string `json:"all"`
-		} `json:"domains,omitempty"`
-		AdminUID int    `json:"admin_uid,omitempty"`
-		Email    string `json:"email,omitempty"`
-		Services []struct {
-			Slug  string `json:"slug"`
-			Ready bool   `json:"ready"`
-		} `json:"services,omitempty"`
-		DiskLimit        int    `json:"disk_limit,omitempty"`
-		SubscriptionPlan string `json:"subscription_plan,omitempty"`
-		Country          string `json:"country,omitempty"`
-		Language         string `json:"language,omitempty"`
-		Name             string `json:"name,omitempty"`
-		Fax              string `json:"fax,omitempty"`
-		DiskUsage        int    `json:"disk_usage,omitempty"`
-		PhoneNumber      string `json:"phone_number,omitempty"`
-	} `json:"result"`
+	Links  interface{}             `json:"links"`
+	Result []DirectoryOrganization `json:"result"`
+}
+
+type DirectoryOrganization struct {
+	Revision         int                          `json:"revision,omitempty"`
+	ID               int                          `json:"id,omitempty"`
+	Label            string                       `json:"label,omitempty"`
+	Domains          DirectoryOrganizationDomains `json:"domains,omitempty"`
+	AdminUID         int                          `json:"admin_uid,omitempty"`
+	Email            string                       `json:"email,omitempty"`
+	Services         []DirectoryService           `json:"services,omitempty"`
+	DiskLimit        int                          `json:"disk_limit,omitempty"`
+	SubscriptionPlan string                       `json:"subscription_plan,omitempty"`
+	Country          string                       `json:"country,omitempty"`
+	Language         string                       `json:"language,omitempty"`
+	Name             string                       `json:"name,omitempty"`
+	Fax              string                       `json:"fax,omitempty"`
+	DiskUsage        int                          `json:"disk_usage,omitempty"`
+	PhoneNumber      string                       `json:"phone_number,omitempty"`
+}
+
+type DirectoryOrganizationDomains struct {
+	Display string   `json:"display"`
+	Master  string   `json:"master"`
+	All     []string `json:"all"`
+}
+
+type DirectoryService struct {
+	Slug  string `json:"slug"`
+	Ready bool   `json:"ready"`
+}
+
+// HasDomain reports whether the domain belongs to the organization, case is ignored
+func (o DirectoryOrganization) HasDomain(domain string) bool {
+	if strings.EqualFold(o.Domains.Master, domain) || strings.EqualFold(o.Domains.Display, domain) {
+		return true
+	}
+	for _, d := range o.Domains.All {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// ByID finds the organization with the ID
+func (o DirectoryOrganizations) ByID(id int) (DirectoryOrganization, bool) {
+	for _, org := range o.Result {
+		if org.ID == id {
+			return org, true
+		}
+	}
+	return DirectoryOrganization{}, false
+}
+
+// ByDomain finds the organization owning the domain
+func (o DirectoryOrganizations) ByDomain(domain string) (DirectoryOrganization, bool) {
+	for _, org := range o.Result {
+		if org.HasDomain(domain) {
+			return org, true
+		}
+	}
+	return DirectoryOrganization{}, false
+}
+
+// Find finds the organization by ID given as a number or by domain
+func (o DirectoryOrganizations) Find(idOrDomain string) (DirectoryOrganization, bool) {
+	if id, err := strconv.Atoi(idOrDomain); err == nil {
+		return o.ByID(id)
+	}
+	return o.ByDomain(idOrDomain)
 }
 
 var DirectoryOrganizationAllParameters = Parameters{
@@ -564,13 +829,31 @@ var DirectoryOrganizationAllParameters = Parameters{
 
 // GetOrganizations ...
 func (d Directory) GetOrganizations(params Parameters) (DirectoryOrganizations, error) {
+	return d.GetOrganizationsContext(context.Background(), params)
+}
+
+// GetOrganizationsContext ...
+func (d Directory) GetOrganizationsContext(ctx context.Context, params Parameters) (DirectoryOrganizations, error) {
 	var organizations DirectoryOrganizations
-	err := Get(
-		d.client,
-		directoryURL+"/organizations/",
+	err := d.get(
+		ctx,
+		d.endpoint("/organizations/"),
 		params,
-		nil,
+		noOrgID,
 		&organizations,
 	)
 	return organizations, err
 }
+
+// GetOrganization ...
+func (d Directory) GetOrganization(orgID int, params Parameters) (DirectoryOrganization, error) {
+	return d.GetOrganizationContext(context.Background(), orgID, params)
+}
+
+// GetOrganizationContext ...
+func (d Directory) GetOrganizationContext(ctx context.Context, orgID int, params Parameters) (DirectoryOrganization, error) {
+	if orgID == 0 {
+		orgID = d.orgID
+	}
+	return get[DirectoryOrganization](ctx, d, d.endpoint("/organizations/{id}/", orgID), orgID, params)
+}
@@ -0,0 +1,156 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDirectoryOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); ua != "sync/1.0" {
+			t.Errorf("need User-Agent 'sync/1.0' but got '%s'", ua)
+		}
+		switch r.URL.Path {
+		case "/proxy/users/5/":
+			if org := r.Header.Get("X-Org-ID"); org != "42" {
+				t.Errorf("need default org 42 but got '%s'", org)
+			}
+			fmt.Fprint(w, `{"id": 5}`)
+		case "/proxy/organizations/":
+			if org := r.Header.Get("X-Org-ID"); org != "" {
+				t.Errorf("organizations must not be scoped but got org '%s'", org)
+			}
+			fmt.Fprint(w, `{"result": []}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	d := NewDirectory(nil,
+		WithHTTPClient(srv.Client()),
+		WithBaseURL(srv.URL+"/proxy/"),
+		WithUserAgent("sync/1.0"),
+		WithDefaultOrgID(42),
+	)
+	if d.BaseURL() != srv.URL+"/proxy" {
+		t.Errorf("base URL '%s'", d.BaseURL())
+	}
+	if user, err := d.GetUser(0, 5, nil); err != nil || user.ID != 5 {
+		t.Errorf("get user: %v, '%v'", user, err)
+	}
+	if _, err := d.GetOrganizations(nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// fakeGroups is an in-memory groups endpoint of the API
+type fakeGroups struct {
+	sync.Mutex
+	t      *testing.T
+	nextID int
+	groups map[int]map[string]interface{}
+}
+
+func newFakeGroups(t *testing.T) *httptest.Server {
+	return httptest.NewServer(newFakeGroupsHandler(t))
+}
+
+func newFakeGroupsHandler(t *testing.T) *fakeGroups {
+	return &fakeGroups{t: t, nextID: 1, groups: map[int]map[string]interface{}{}}
+}
+
+func (f *fakeGroups) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	if r.Header.Get("X-Org-ID") != "1" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/groups"), "/")
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPatch {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if path == "" && r.Method == http.MethodPost {
+		if body["name"] == nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"code":"required_field","message":"name is required"}`)
+			return
+		}
+		body["id"] = f.nextID
+		f.groups[f.nextID] = body
+		f.nextID++
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	id, err := strconv.Atoi(path)
+	group, ok := f.groups[id]
+	if err != nil || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"not_found","message":"Not found"}`)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(group)
+	case http.MethodPatch:
+		for k, v := range body {
+			group[k] = v
+		}
+		json.NewEncoder(w).Encode(group)
+	case http.MethodDelete:
+		delete(f.groups, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestGroupCRUD(t *testing.T) {
+	srv := newFakeGroups(t)
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	group, err := d.CreateGroup(1, DirectoryNewGroup{
+		Name:    "Sales",
+		Label:   "sales",
+		Members: []DirectoryGroupMember{UserMember(10), DepartmentMember(2)},
+		Admins:  []DirectoryGroupAdmin{{ID: 10}},
+	})
+	if err != nil || group.ID != 1 || group.Name != "Sales" || len(group.Members) != 2 || group.Members[1].Type != MemberDepartment {
+		t.Fatalf("create: %+v, '%v'", group, err)
+	}
+
+	group, err = d.ModifyGroup(1, group.ID, DirectoryNewGroup{Description: "Sales team"})
+	if err != nil || group.Name != "Sales" || group.Description != "Sales team" {
+		t.Fatalf("modify: %+v, '%v'", group, err)
+	}
+
+	if err = d.DeleteGroup(1, group.ID); err != nil {
+		t.Fatalf("delete: '%v'", err)
+	}
+	if _, err = d.GetGroup(1, group.ID, nil); !IsNotFound(err) {
+		t.Errorf("need not found after delete but got '%v'", err)
+	}
+	if _, err = d.ModifyGroup(1, 100, DirectoryNewGroup{Name: "x"}); !IsNotFound(err) {
+		t.Errorf("need not found for unknown group but got '%v'", err)
+	}
+	if _, err = d.CreateGroup(1, DirectoryNewGroup{}); !IsCode(err, "required_field") {
+		t.Errorf("need required_field error but got '%v'", err)
+	}
+}
@@ -0,0 +1,328 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// DNSRecordType is the type of a DNS record
+type DNSRecordType string
+
+const (
+	DNSTypeA     DNSRecordType = "A"
+	DNSTypeAAAA  DNSRecordType = "AAAA"
+	DNSTypeCNAME DNSRecordType = "CNAME"
+	DNSTypeMX    DNSRecordType = "MX"
+	DNSTypeTXT   DNSRecordType = "TXT"
+	DNSTypeSRV   DNSRecordType = "SRV"
+	DNSTypeNS    DNSRecordType = "NS"
+)
+
+// DirectoryDNSRecord is a DNS record of a domain delegated to Yandex as the API returns it.
+// Only the fields of its type are set, use Typed to get the typed record.
+type DirectoryDNSRecord struct {
+	ID         int           `json:"record_id,omitempty"`
+	Type       DNSRecordType `json:"type"`
+	Name       string        `json:"name"`
+	TTL        int           `json:"ttl,omitempty"`
+	Address    string        `json:"address,omitempty"`
+	Target     string        `json:"target,omitempty"`
+	Exchange   string        `json:"exchange,omitempty"`
+	Preference int           `json:"preference,omitempty"`
+	Text       string        `json:"text,omitempty"`
+	Priority   int           `json:"priority,omitempty"`
+	Weight     int           `json:"weight,omitempty"`
+	Port       int           `json:"port,omitempty"`
+}
+
+// DirectoryDNSRecords is a page of DNS records
+type DirectoryDNSRecords = Page[DirectoryDNSRecord]
+
+// DNSRecord is one of the typed records: ARecord, AAAARecord, CNAMERecord, MXRecord, TXTRecord, SRVRecord, NSRecord
+type DNSRecord interface {
+	Record() DirectoryDNSRecord
+}
+
+// ARecord maps a name to an IPv4 address
+type ARecord struct {
+	Name    string
+	TTL     int
+	Address string
+}
+
+// AAAARecord maps a name to an IPv6 address
+type AAAARecord struct {
+	Name    string
+	TTL     int
+	Address string
+}
+
+// CNAMERecord makes a name an alias of Target
+type CNAMERecord struct {
+	Name   string
+	TTL    int
+	Target string
+}
+
+// MXRecord routes the mail of a name to Exchange
+type MXRecord struct {
+	Name       string
+	TTL        int
+	Exchange   string
+	Preference int
+}
+
+// TXTRecord holds a text, e.g. SPF or DKIM
+type TXTRecord struct {
+	Name string
+	TTL  int
+	Text string
+}
+
+// SRVRecord points a service to Target:Port
+type SRVRecord struct {
+	Name     string
+	TTL      int
+	Target   string
+	Priority int
+	Weight   int
+	Port     int
+}
+
+// NSRecord delegates a name to the name server Target
+type NSRecord struct {
+	Name   string
+	TTL    int
+	Target string
+}
+
+func (r ARecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeA, Name: r.Name, TTL: r.TTL, Address: r.Address}
+}
+
+func (r AAAARecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeAAAA, Name: r.Name, TTL: r.TTL, Address: r.Address}
+}
+
+func (r CNAMERecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeCNAME, Name: r.Name, TTL: r.TTL, Target: r.Target}
+}
+
+func (r MXRecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeMX, Name: r.Name, TTL: r.TTL, Exchange: r.Exchange, Preference: r.Preference}
+}
+
+func (r TXTRecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeTXT, Name: r.Name, TTL: r.TTL, Text: r.Text}
+}
+
+func (r SRVRecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeSRV, Name: r.Name, TTL: r.TTL, Target: r.Target, Priority: r.Priority, Weight: r.Weight, Port: r.Port}
+}
+
+func (r NSRecord) Record() DirectoryDNSRecord {
+	return DirectoryDNSRecord{Type: DNSTypeNS, Name: r.Name, TTL: r.TTL, Target: r.Target}
+}
+
+// Record returns the record itself, so that API records can be passed as DNSRecord
+func (r DirectoryDNSRecord) Record() DirectoryDNSRecord {
+	return r
+}
+
+// Typed returns the typed record, nil for an unknown type
+func (r DirectoryDNSRecord) Typed() DNSRecord {
+	switch r.Type {
+	case DNSTypeA:
+		return ARecord{Name: r.Name, TTL: r.TTL, Address: r.Address}
+	case DNSTypeAAAA:
+		return AAAARecord{Name: r.Name, TTL: r.TTL, Address: r.Address}
+	case DNSTypeCNAME:
+		return CNAMERecord{Name: r.Name, TTL: r.TTL, Target: r.Target}
+	case DNSTypeMX:
+		return MXRecord{Name: r.Name, TTL: r.TTL, Exchange: r.Exchange, Preference: r.Preference}
+	case DNSTypeTXT:
+		return TXTRecord{Name: r.Name, TTL: r.TTL, Text: r.Text}
+	case DNSTypeSRV:
+		return SRVRecord{Name: r.Name, TTL: r.TTL, Target: r.Target, Priority: r.Priority, Weight: r.Weight, Port: r.Port}
+	case DNSTypeNS:
+		return NSRecord{Name: r.Name, TTL: r.TTL, Target: r.Target}
+	}
+	return nil
+}
+
+// key identifies the record in a record set: a name has one CNAME,
+// other records with the same name differ by their value
+func (r DirectoryDNSRecord) key() string {
+	name := normalizeDNSName(r.Name)
+	switch r.Type {
+	case DNSTypeCNAME:
+		return string(r.Type) + " " + name
+	case DNSTypeA, DNSTypeAAAA:
+		return string(r.Type) + " " + name + " " + r.Address
+	case DNSTypeMX:
+		return string(r.Type) + " " + name + " " + normalizeDNSName(r.Exchange)
+	case DNSTypeTXT:
+		return string(r.Type) + " " + name + " " + r.Text
+	case DNSTypeSRV:
+		return string(r.Type) + " " + name + " " + normalizeDNSName(r.Target) + ":" + strconv.Itoa(r.Port)
+	}
+	return string(r.Type) + " " + name + " " + normalizeDNSName(r.Target)
+}
+
+// equal compares everything but the ID, a zero TTL of r matches any TTL
+func (r DirectoryDNSRecord) equal(other DirectoryDNSRecord) bool {
+	if r.TTL == 0 {
+		r.TTL = other.TTL
+	}
+	r.ID, other.ID = 0, 0
+	r.Name, other.Name = normalizeDNSName(r.Name), normalizeDNSName(other.Name)
+	r.Target, other.Target = normalizeDNSName(r.Target), normalizeDNSName(other.Target)
+	r.Exchange, other.Exchange = normalizeDNSName(r.Exchange), normalizeDNSName(other.Exchange)
+	return r == other
+}
+
+// normalizeDNSName makes "@" and "" the same and drops the trailing dot
+func normalizeDNSName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// DNSUpdate is a current record to be edited to the desired one
+type DNSUpdate struct {
+	Current DirectoryDNSRecord
+	Desired DNSRecord
+}
+
+// DNSDiff is the difference between a desired record set and the current one
+type DNSDiff struct {
+	Add    []DNSRecord
+	Update []DNSUpdate
+	Delete []DirectoryDNSRecord
+}
+
+// Empty reports whether the record sets are the same
+func (diff DNSDiff) Empty() bool {
+	return len(diff.Add) == 0 && len(diff.Update) == 0 && len(diff.Delete) == 0
+}
+
+// DiffDNSRecords compares the desired record set with the current one.
+// Records are matched by type, name and value (the name alone for CNAME),
+// matched records with other TTL or priorities are updated.
+// A zero TTL in a desired record matches any TTL.
+func DiffDNSRecords(desired []DNSRecord, current []DirectoryDNSRecord) DNSDiff {
+	var diff DNSDiff
+	byKey := make(map[string][]int, len(current))
+	for i, r := range current {
+		byKey[r.key()] = append(byKey[r.key()], i)
+	}
+	matched := make([]bool, len(current))
+	for _, d := range desired {
+		want := d.Record()
+		k := want.key()
+		found := byKey[k]
+		if len(found) == 0 {
+			diff.Add = append(diff.Add, d)
+			continue
+		}
+		byKey[k] = found[1:]
+		matched[found[0]] = true
+		if have := current[found[0]]; !want.equal(have) {
+			diff.Update = append(diff.Update, DNSUpdate{Current: have, Desired: d})
+		}
+	}
+	for i, r := range current {
+		if !matched[i] {
+			diff.Delete = append(diff.Delete, r)
+		}
+	}
+	return diff
+}
+
+// ListDNSRecords returns all the DNS records of the domain
+func (d Directory) ListDNSRecords(orgID int, domain string) ([]DirectoryDNSRecord, error) {
+	return d.ListDNSRecordsContext(context.Background(), orgID, domain)
+}
+
+// ListDNSRecordsContext ...
+func (d Directory) ListDNSRecordsContext(ctx context.Context, orgID int, domain string) ([]DirectoryDNSRecord, error) {
+	return fetchItems(ctx, listPages[DirectoryDNSRecord](d, d.endpoint("/domains/{domain}/dns/", domain), orgID), nil, 1)
+}
+
+// AddDNSRecord adds the record to the domain
+func (d Directory) AddDNSRecord(orgID int, domain string, record DNSRecord) (DirectoryDNSRecord, error) {
+	return d.AddDNSRecordContext(context.Background(), orgID, domain, record)
+}
+
+// AddDNSRecordContext ...
+func (d Directory) AddDNSRecordContext(ctx context.Context, orgID int, domain string, record DNSRecord) (DirectoryDNSRecord, error) {
+	var created DirectoryDNSRecord
+	r := record.Record()
+	r.ID = 0
+	j, err := json.Marshal(r)
+	if err != nil {
+		return created, err
+	}
+	err = d.post(
+		ctx,
+		d.endpoint("/domains/{domain}/dns/", domain),
+		nil,
+		orgID,
+		j,
+		&created,
+	)
+	return created, err
+}
+
+// EditDNSRecord replaces the record recordID of the domain
+func (d Directory) EditDNSRecord(orgID int, domain string, recordID int, record DNSRecord) (DirectoryDNSRecord, error) {
+	return d.EditDNSRecordContext(context.Background(), orgID, domain, recordID, record)
+}
+
+// EditDNSRecordContext ...
+func (d Directory) EditDNSRecordContext(ctx context.Context, orgID int, domain string, recordID int, record DNSRecord) (DirectoryDNSRecord, error) {
+	r := record.Record()
+	r.ID = 0
+	return patchObject[DirectoryDNSRecord](ctx, d, d.endpoint("/domains/{domain}/dns/{id}/", domain, recordID), orgID, r)
+}
+
+// DeleteDNSRecord removes the record recordID from the domain
+func (d Directory) DeleteDNSRecord(orgID int, domain string, recordID int) error {
+	return d.DeleteDNSRecordContext(context.Background(), orgID, domain, recordID)
+}
+
+// DeleteDNSRecordContext ...
+func (d Directory) DeleteDNSRecordContext(ctx context.Context, orgID int, domain string, recordID int) error {
+	return d.delete(ctx, d.endpoint("/domains/{domain}/dns/{id}/", domain, recordID), nil, orgID)
+}
+
+// ApplyDNSDiff deletes, edits and adds the records of the domain as the diff says.
+// Old records are deleted first, so that a name can change e.g. from A to CNAME.
+// It stops at the first error.
+func (d Directory) ApplyDNSDiff(orgID int, domain string, diff DNSDiff) error {
+	return d.ApplyDNSDiffContext(context.Background(), orgID, domain, diff)
+}
+
+// ApplyDNSDiffContext ...
+func (d Directory) ApplyDNSDiffContext(ctx context.Context, orgID int, domain string, diff DNSDiff) error {
+	for _, r := range diff.Delete {
+		if err := d.DeleteDNSRecordContext(ctx, orgID, domain, r.ID); err != nil {
+			return err
+		}
+	}
+	for _, u := range diff.Update {
+		if _, err := d.EditDNSRecordContext(ctx, orgID, domain, u.Current.ID, u.Desired); err != nil {
+			return err
+		}
+	}
+	for _, r := range diff.Add {
+		if _, err := d.AddDNSRecordContext(ctx, orgID, domain, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDNS keeps the records of one domain, listing them two per page
+type fakeDNS struct {
+	mu      sync.Mutex
+	records []DirectoryDNSRecord
+	nextID  int
+}
+
+func (f *fakeDNS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/domains/example.com/dns/"), "/"))
+	switch r.Method {
+	case http.MethodGet:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		result := f.records[min(len(f.records), (page-1)*2):min(len(f.records), page*2)]
+		json.NewEncoder(w).Encode(DirectoryDNSRecords{Page: page, Pages: (len(f.records) + 1) / 2, Result: result})
+	case http.MethodPost, http.MethodPatch:
+		var rec DirectoryDNSRecord
+		json.NewDecoder(r.Body).Decode(&rec)
+		if r.Method == http.MethodPost {
+			for _, have := range f.records {
+				// a CNAME can not share its name with other records
+				if have.Name == rec.Name && (have.Type == DNSTypeCNAME || rec.Type == DNSTypeCNAME) {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+			}
+			f.nextID++
+			rec.ID = f.nextID
+			f.records = append(f.records, rec)
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			for i := range f.records {
+				if f.records[i].ID == id {
+					rec.ID = id
+					f.records[i] = rec
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(rec)
+	case http.MethodDelete:
+		for i := range f.records {
+			if f.records[i].ID == id {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestDiffDNSRecords(t *testing.T) {
+	current := []DirectoryDNSRecord{
+		{ID: 1, Type: DNSTypeMX, Name: "@", TTL: 21600, Exchange: "mx.yandex.net.", Preference: 10},
+		{ID: 2, Type: DNSTypeCNAME, Name: "mail", TTL: 21600, Target: "domain.mail.yandex.net."},
+		{ID: 3, Type: DNSTypeTXT, Name: "@", TTL: 21600, Text: "v=spf1 -all"},
+		{ID: 4, Type: DNSTypeA, Name: "www", TTL: 300, Address: "192.0.2.1"},
+	}
+	desired := []DNSRecord{
+		MXRecord{Name: "", Exchange: "mx.yandex.net", Preference: 10},
+		CNAMERecord{Name: "mail", TTL: 21600, Target: "other.example.net"},
+		TXTRecord{Name: "@", Text: "v=spf1 redirect=_spf.yandex.net"},
+		ARecord{Name: "www", TTL: 300, Address: "192.0.2.1"},
+	}
+	diff := DiffDNSRecords(desired, current)
+	if len(diff.Add) != 1 || diff.Add[0] != desired[2] {
+		t.Errorf("add: %v", diff.Add)
+	}
+	if len(diff.Update) != 1 || diff.Update[0].Current.ID != 2 {
+		t.Errorf("update: %v", diff.Update)
+	}
+	if len(diff.Delete) != 1 || diff.Delete[0].ID != 3 {
+		t.Errorf("delete: %v", diff.Delete)
+	}
+	if diff := DiffDNSRecords(desired[3:], current[3:]); !diff.Empty() {
+		t.Errorf("need empty diff but got %v", diff)
+	}
+}
+
+func TestDNSRecordTyped(t *testing.T) {
+	records := []DNSRecord{
+		ARecord{Name: "@", TTL: 1, Address: "192.0.2.1"},
+		AAAARecord{Name: "@", TTL: 1, Address: "2001:db8::1"},
+		CNAMERecord{Name: "www", TTL: 1, Target: "example.com."},
+		MXRecord{Name: "@", TTL: 1, Exchange: "mx.yandex.net.", Preference: 10},
+		TXTRecord{Name: "@", TTL: 1, Text: "v=spf1"},
+		SRVRecord{Name: "_xmpp._tcp", TTL: 1, Target: "xmpp.example.com.", Priority: 1, Weight: 2, Port: 5222},
+		NSRecord{Name: "sub", TTL: 1, Target: "ns1.example.com."},
+	}
+	for _, r := range records {
+		if typed := r.Record().Typed(); typed != r {
+			t.Errorf("got %v from %v", typed, r)
+		}
+	}
+	if (DirectoryDNSRecord{Type: "CAA"}).Typed() != nil {
+		t.Error("need nil for an unknown type")
+	}
+}
+
+func TestDNSSync(t *testing.T) {
+	fake := &fakeDNS{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	for _, r := range []DNSRecord{
+		ARecord{Name: "@", TTL: 300, Address: "192.0.2.1"},
+		TXTRecord{Name: "@", TTL: 300, Text: "v=spf1 -all"},
+		CNAMERecord{Name: "mail", TTL: 300, Target: "old.example.net."},
+		ARecord{Name: "www", TTL: 300, Address: "192.0.2.1"},
+	} {
+		if _, err := d.AddDNSRecord(1, "example.com", r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	desired := []DNSRecord{
+		ARecord{Name: "@", TTL: 300, Address: "192.0.2.1"},
+		MXRecord{Name: "@", TTL: 21600, Exchange: "mx.yandex.net.", Preference: 10},
+		TXTRecord{Name: "@", TTL: 21600, Text: "v=spf1 redirect=_spf.yandex.net"},
+		CNAMERecord{Name: "mail", TTL: 21600, Target: "domain.mail.yandex.net."},
+		CNAMERecord{Name: "www", TTL: 300, Target: "example.com."},
+	}
+	current, err := d.ListDNSRecords(1, "example.com")
+	if err != nil || len(current) != 4 {
+		t.Fatalf("list: %v, '%v'", current, err)
+	}
+	diff := DiffDNSRecords(desired, current)
+	if len(diff.Add) != 3 || len(diff.Update) != 1 || len(diff.Delete) != 2 {
+		t.Fatalf("got diff %+v", diff)
+	}
+	if err := d.ApplyDNSDiffContext(ctx, 1, "example.com", diff); err != nil {
+		t.Fatal(err)
+	}
+
+	current, err = d.ListDNSRecords(1, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := DiffDNSRecords(desired, current); !diff.Empty() {
+		t.Errorf("need empty diff after apply but got %+v", diff)
+	}
+}
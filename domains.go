@@ -0,0 +1,238 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// DomainVerificationMethod is a way to prove the ownership of a domain
+type DomainVerificationMethod string
+
+const (
+	VerificationDNS      DomainVerificationMethod = "webmaster.dns"
+	VerificationMetaTag  DomainVerificationMethod = "webmaster.meta_tag"
+	VerificationHTMLFile DomainVerificationMethod = "webmaster.html_file"
+)
+
+// Ownership statuses of DirectoryDomainOwnership
+const (
+	DomainOwned          = "owned"
+	DomainNeedValidation = "need-validation"
+	DomainInProgress     = "in-progress"
+)
+
+// DirectoryDomainOwnership is the ownership status of a domain with the data to verify it
+type DirectoryDomainOwnership struct {
+	Domain        string                        `json:"domain"`
+	Status        string                        `json:"status"`
+	Methods       []DirectoryDomainVerification `json:"methods,omitempty"`
+	LastCheck     *DirectoryDomainCheck         `json:"last_check,omitempty"`
+	PreferredHost string                        `json:"preferred_host,omitempty"`
+}
+
+// DirectoryDomainVerification is the code to publish for a verification method
+type DirectoryDomainVerification struct {
+	Method DomainVerificationMethod `json:"method"`
+	Code   string                   `json:"code"`
+	Weight int                      `json:"weight"`
+}
+
+// DirectoryDomainCheck is the result of the last ownership check
+type DirectoryDomainCheck struct {
+	Date   string                   `json:"date"`
+	Method DomainVerificationMethod `json:"method"`
+	Fail   string                   `json:"fail_type,omitempty"`
+}
+
+// Owned reports whether the ownership is confirmed
+func (o DirectoryDomainOwnership) Owned() bool {
+	return o.Status == DomainOwned
+}
+
+// Method returns the verification data of the method
+func (o DirectoryDomainOwnership) Method(method DomainVerificationMethod) (DirectoryDomainVerification, bool) {
+	for _, m := range o.Methods {
+		if m.Method == method {
+			return m, true
+		}
+	}
+	return DirectoryDomainVerification{}, false
+}
+
+// Publish returns what to publish to pass the verification:
+// the TXT record value, the meta tag or the file path relative to the site root
+func (v DirectoryDomainVerification) Publish() string {
+	switch v.Method {
+	case VerificationDNS:
+		return "yandex-verification: " + v.Code
+	case VerificationMetaTag:
+		return `<meta name="yandex-verification" content="` + v.Code + `" />`
+	case VerificationHTMLFile:
+		return "/yandex_" + v.Code + ".html"
+	}
+	return v.Code
+}
+
+// AddDomain adds the domain to the organization,
+// a domain already added to another organization is returned as *ConflictError
+func (d Directory) AddDomain(orgID int, domain string) (DirectoryDomain, error) {
+	return d.AddDomainContext(context.Background(), orgID, domain)
+}
+
+// AddDomainContext ...
+func (d Directory) AddDomainContext(ctx context.Context, orgID int, domain string) (DirectoryDomain, error) {
+	var created DirectoryDomain
+	j, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{domain})
+	if err != nil {
+		return created, err
+	}
+	err = d.post(
+		ctx,
+		d.endpoint("/domains/"),
+		nil,
+		orgID,
+		j,
+		&created,
+	)
+	if IsConflict(err) {
+		err = &ConflictError{Kind: "domain", Name: domain, Err: err}
+	}
+	return created, err
+}
+
+// GetDomainOwnership returns the ownership status and the verification data of the domain
+func (d Directory) GetDomainOwnership(orgID int, domain string) (DirectoryDomainOwnership, error) {
+	return d.GetDomainOwnershipContext(context.Background(), orgID, domain)
+}
+
+// GetDomainOwnershipContext ...
+func (d Directory) GetDomainOwnershipContext(ctx context.Context, orgID int, domain string) (DirectoryDomainOwnership, error) {
+	return get[DirectoryDomainOwnership](ctx, d, d.endpoint("/domains/{domain}/check-ownership/", domain), orgID, nil)
+}
+
+// CheckDomainOwnership asks the API to verify the domain with the method,
+// the result is read later with GetDomainOwnership
+func (d Directory) CheckDomainOwnership(orgID int, domain string, method DomainVerificationMethod) (DirectoryDomainOwnership, error) {
+	return d.CheckDomainOwnershipContext(context.Background(), orgID, domain, method)
+}
+
+// CheckDomainOwnershipContext ...
+func (d Directory) CheckDomainOwnershipContext(ctx context.Context, orgID int, domain string, method DomainVerificationMethod) (DirectoryDomainOwnership, error) {
+	var ownership DirectoryDomainOwnership
+	j, err := json.Marshal(struct {
+		VerificationType DomainVerificationMethod `json:"verification_type"`
+	}{method})
+	if err != nil {
+		return ownership, err
+	}
+	err = d.request(
+		ctx,
+		http.MethodPost,
+		d.endpoint("/domains/{domain}/check-ownership/", domain),
+		nil,
+		orgID,
+		http.StatusOK,
+		j,
+		&ownership,
+	)
+	return ownership, err
+}
+
+// DeleteDomain removes the domain from the organization
+func (d Directory) DeleteDomain(orgID int, domain string) error {
+	return d.DeleteDomainContext(context.Background(), orgID, domain)
+}
+
+// DeleteDomainContext ...
+func (d Directory) DeleteDomainContext(ctx context.Context, orgID int, domain string) error {
+	return d.delete(ctx, d.endpoint("/domains/{domain}/", domain), nil, orgID)
+}
+
+// DefaultDKIMSelector is the selector used when the API returns none
+const DefaultDKIMSelector = "mail"
+
+// DirectoryDKIM is the DKIM signing status of a domain
+type DirectoryDKIM struct {
+	Enabled   bool   `json:"enabled"`
+	Selector  string `json:"selector,omitempty"`
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+// TXTRecord returns the record to publish, the name is relative to the domain
+func (s DirectoryDKIM) TXTRecord() TXTRecord {
+	selector := s.Selector
+	if selector == "" {
+		selector = DefaultDKIMSelector
+	}
+	text := s.PublicKey
+	if !strings.HasPrefix(text, "v=DKIM1") {
+		text = "v=DKIM1; k=rsa; t=s; p=" + text
+	}
+	return TXTRecord{Name: selector + "._domainkey", Text: text}
+}
+
+// GetDKIM returns the DKIM status of the domain
+func (d Directory) GetDKIM(orgID int, domain string) (DirectoryDKIM, error) {
+	return d.GetDKIMContext(context.Background(), orgID, domain)
+}
+
+// GetDKIMContext ...
+func (d Directory) GetDKIMContext(ctx context.Context, orgID int, domain string) (DirectoryDKIM, error) {
+	return get[DirectoryDKIM](ctx, d, d.endpoint("/domains/{domain}/dkim/", domain), orgID, nil)
+}
+
+// EnableDKIM turns on DKIM signing of the domain mail
+func (d Directory) EnableDKIM(orgID int, domain string) (DirectoryDKIM, error) {
+	return d.EnableDKIMContext(context.Background(), orgID, domain)
+}
+
+// EnableDKIMContext ...
+func (d Directory) EnableDKIMContext(ctx context.Context, orgID int, domain string) (DirectoryDKIM, error) {
+	return d.SetDKIMContext(ctx, orgID, domain, true)
+}
+
+// DisableDKIM turns off DKIM signing of the domain mail
+func (d Directory) DisableDKIM(orgID int, domain string) (DirectoryDKIM, error) {
+	return d.DisableDKIMContext(context.Background(), orgID, domain)
+}
+
+// DisableDKIMContext ...
+func (d Directory) DisableDKIMContext(ctx context.Context, orgID int, domain string) (DirectoryDKIM, error) {
+	return d.SetDKIMContext(ctx, orgID, domain, false)
+}
+
+// SetDKIM turns DKIM signing of the domain mail on or off
+func (d Directory) SetDKIM(orgID int, domain string, enabled bool) (DirectoryDKIM, error) {
+	return d.SetDKIMContext(context.Background(), orgID, domain, enabled)
+}
+
+// SetDKIMContext ...
+func (d Directory) SetDKIMContext(ctx context.Context, orgID int, domain string, enabled bool) (DirectoryDKIM, error) {
+	return patchObject[DirectoryDKIM](ctx, d, d.endpoint("/domains/{domain}/dkim/", domain), orgID, struct {
+		Enabled bool `json:"enabled"`
+	}{enabled})
+}
+
+// DKIMRecord returns the exact TXT record to publish for the domain,
+// its name is the full one, e.g. mail._domainkey.example.com
+func (d Directory) DKIMRecord(orgID int, domain DirectoryDomain) (TXTRecord, error) {
+	return d.DKIMRecordContext(context.Background(), orgID, domain)
+}
+
+// DKIMRecordContext ...
+func (d Directory) DKIMRecordContext(ctx context.Context, orgID int, domain DirectoryDomain) (TXTRecord, error) {
+	dkim, err := d.GetDKIMContext(ctx, orgID, domain.Name)
+	if err != nil {
+		return TXTRecord{}, err
+	}
+	if dkim.PublicKey == "" {
+		return TXTRecord{}, &NotFoundError{Kind: "DKIM key of domain", Name: domain.Name}
+	}
+	record := dkim.TXTRecord()
+	record.Name += "." + domain.Name
+	return record, nil
+}
@@ -0,0 +1,112 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDomains(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.Path+" "+string(body))
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"domain": "example.com", "status": "need-validation", "methods": [
+				{"method": "webmaster.dns", "code": "abc", "weight": 0},
+				{"method": "webmaster.html_file", "code": "abc", "weight": 1}]}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/domains/example.com/check-ownership/":
+			fmt.Fprint(w, `{"domain": "example.com", "status": "in-progress"}`)
+		case string(body) == `{"name":"taken.com"}`:
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code": "domain_occupied", "message": "Domain already added to another organization"}`)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"name": "example.com", "master": true}`)
+		}
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if domain, err := d.AddDomain(1, "example.com"); err != nil || domain.Name != "example.com" {
+		t.Errorf("add domain: %v, '%v'", domain, err)
+	}
+	ownership, err := d.GetDomainOwnership(1, "example.com")
+	if err != nil || ownership.Owned() {
+		t.Fatalf("get ownership: %v, '%v'", ownership, err)
+	}
+	if m, ok := ownership.Method(VerificationDNS); !ok || m.Publish() != "yandex-verification: abc" {
+		t.Errorf("dns verification: %v", m)
+	}
+	if m, ok := ownership.Method(VerificationHTMLFile); !ok || m.Publish() != "/yandex_abc.html" {
+		t.Errorf("file verification: %v", m)
+	}
+	if _, ok := ownership.Method(VerificationMetaTag); ok {
+		t.Error("need no meta tag verification")
+	}
+	if ownership, err := d.CheckDomainOwnership(1, "example.com", VerificationDNS); err != nil || ownership.Status != DomainInProgress {
+		t.Errorf("check ownership: %v, '%v'", ownership, err)
+	}
+	if err := d.DeleteDomain(1, "example.com"); err != nil {
+		t.Errorf("delete domain: '%v'", err)
+	}
+
+	_, err = d.AddDomain(1, "taken.com")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || conflict.Kind != "domain" || conflict.Name != "taken.com" {
+		t.Errorf("need conflict error but got '%v'", err)
+	}
+
+	need := `[POST /domains/ {"name":"example.com"} GET /domains/example.com/check-ownership/  ` +
+		`POST /domains/example.com/check-ownership/ {"verification_type":"webmaster.dns"} ` +
+		`DELETE /domains/example.com/  POST /domains/ {"name":"taken.com"}]`
+	if fmt.Sprint(got) != need {
+		t.Errorf("got requests %v", got)
+	}
+}
+
+func TestDKIM(t *testing.T) {
+	enabled := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			var body struct{ Enabled bool }
+			json.NewDecoder(r.Body).Decode(&body)
+			enabled = body.Enabled
+		}
+		if r.URL.Path == "/domains/nokey.com/dkim/" {
+			fmt.Fprint(w, `{"enabled": false}`)
+			return
+		}
+		fmt.Fprintf(w, `{"enabled": %v, "selector": "mail", "public_key": "MIGfMA0"}`, enabled)
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if dkim, err := d.EnableDKIM(1, "example.com"); err != nil || !dkim.Enabled {
+		t.Errorf("enable: %v, '%v'", dkim, err)
+	}
+	if dkim, err := d.DisableDKIM(1, "example.com"); err != nil || dkim.Enabled {
+		t.Errorf("disable: %v, '%v'", dkim, err)
+	}
+	if dkim, err := d.SetDKIM(1, "example.com", true); err != nil || !dkim.Enabled {
+		t.Errorf("set: %v, '%v'", dkim, err)
+	}
+	record, err := d.DKIMRecord(1, DirectoryDomain{Name: "example.com"})
+	need := TXTRecord{Name: "mail._domainkey.example.com", Text: "v=DKIM1; k=rsa; t=s; p=MIGfMA0"}
+	if err != nil || record != need {
+		t.Errorf("got record %v, '%v'", record, err)
+	}
+	if _, err := d.DKIMRecord(1, DirectoryDomain{Name: "nokey.com"}); !IsNotFound(err) {
+		t.Errorf("need not found but got '%v'", err)
+	}
+	if r := (DirectoryDKIM{PublicKey: "v=DKIM1; k=rsa; p=KEY"}).TXTRecord(); r.Name != "mail._domainkey" || r.Text != "v=DKIM1; k=rsa; p=KEY" {
+		t.Errorf("got record %v", r)
+	}
+}
@@ -0,0 +1,136 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBody limits how much of an unexpected response is kept in APIError.Body
+const maxErrorBody = 1 << 20
+
+// APIError is returned when the API answers with a status other than expected.
+// Code, Message and Params are decoded from the Yandex error body if it is JSON.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Method     string `json:"-"`
+	URL        string `json:"-"`
+
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params"`
+
+	// Authenticate is the WWW-Authenticate header of a 401/403 response
+	Authenticate string `json:"-"`
+
+	Header http.Header `json:"-"`
+	Body   []byte      `json:"-"`
+}
+
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode:   resp.StatusCode,
+		Status:       resp.Status,
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		Authenticate: resp.Header.Get("WWW-Authenticate"),
+		Header:       resp.Header,
+		Body:         body,
+	}
+	// the body is not always JSON (proxies, html error pages), the raw body is kept anyway
+	_ = json.Unmarshal(body, e)
+	return e
+}
+
+func (e *APIError) Error() string {
+	s := &strings.Builder{}
+	s.WriteString(e.Method + " " + e.URL + ": ")
+	if e.Status != "" {
+		s.WriteString(e.Status)
+	} else {
+		s.WriteString(strconv.Itoa(e.StatusCode))
+	}
+	if e.Code != "" {
+		s.WriteString(" " + e.Code)
+	}
+	if e.Message != "" {
+		s.WriteString(": " + e.Message)
+	}
+	if e.Authenticate != "" {
+		s.WriteString(" " + e.Authenticate)
+	}
+	return s.String()
+}
+
+// IsStatus reports whether err is an *APIError with the given HTTP status
+func IsStatus(err error, status int) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.StatusCode == status
+}
+
+// IsCode reports whether err is an *APIError with the given Yandex error code
+func IsCode(err error, code string) bool {
+	var e *APIError
+	return errors.As(err, &e) && e.Code == code
+}
+
+// NotFoundError reports that an object referenced by a call does not exist,
+// e.g. a user passed as a group admin. Err is the API error if there was one.
+type NotFoundError struct {
+	Kind string // user, group, group admin, ...
+	ID   int
+	Name string // set instead of ID for objects identified by name
+	Err  error
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Name != "" {
+		return e.Kind + " " + e.Name + " not found"
+	}
+	return e.Kind + " " + strconv.Itoa(e.ID) + " not found"
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError reports that an object with the same name already exists,
+// e.g. a duplicate alias. Err is the API error.
+type ConflictError struct {
+	Kind string // alias, domain, ...
+	Name string
+	Err  error
+}
+
+func (e *ConflictError) Error() string {
+	return e.Kind + " " + e.Name + " already exists"
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is a 404 *APIError or a *NotFoundError
+func IsNotFound(err error) bool {
+	var e *NotFoundError
+	return errors.As(err, &e) || IsStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 *APIError or a *ConflictError
+func IsConflict(err error) bool {
+	var e *ConflictError
+	return errors.As(err, &e) || IsStatus(err, http.StatusConflict)
+}
+
+// IsForbidden ...
+func IsForbidden(err error) bool {
+	return IsStatus(err, http.StatusForbidden)
+}
+
+// IsUnauthorized ...
+func IsUnauthorized(err error) bool {
+	return IsStatus(err, http.StatusUnauthorized)
+}
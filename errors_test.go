@@ -0,0 +1,44 @@
+package go_yapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/":
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"code":"some_user_has_this_login","message":"Some user already exists with login \"{login}\"","params":{"login":"test"}}`)
+		case "/forbidden/":
+			w.Header().Set("WWW-Authenticate", `OAuth realm="directory", error="invalid_token"`)
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<html>not found</html>")
+		}
+	}))
+	defer srv.Close()
+
+	err := Post(srv.Client(), srv.URL+"/users/", nil, nil, nil, nil)
+	if !IsConflict(err) || IsNotFound(err) {
+		t.Fatalf("need conflict error but got '%v'", err)
+	}
+	e := err.(*APIError)
+	if e.Code != "some_user_has_this_login" || e.Params["login"] != "test" || e.Method != http.MethodPost {
+		t.Errorf("error body decoded wrong: %+v", e)
+	}
+
+	err = Get(srv.Client(), srv.URL+"/forbidden/", nil, nil, nil)
+	if !IsForbidden(err) || err.(*APIError).Authenticate == "" {
+		t.Errorf("need forbidden error with WWW-Authenticate but got '%v'", err)
+	}
+
+	err = Get(srv.Client(), srv.URL+"/departments/1/", nil, nil, nil)
+	if !IsNotFound(err) || string(err.(*APIError).Body) != "<html>not found</html>" {
+		t.Errorf("need not found error with raw body but got '%v'", err)
+	}
+}
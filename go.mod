@@ -1,5 +1,11 @@
 module go-yapi
 
-go 1.13
+go 1.23
 
 require golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
+
+require (
+	github.com/golang/protobuf v1.2.0 // indirect
+	golang.org/x/net v0.0.0-20190108225652-1e06a53dbb7e // indirect
+	google.golang.org/appengine v1.4.0 // indirect
+)
@@ -0,0 +1,124 @@
+package go_yapi
+
+import "context"
+
+// ListGroupAdmins ...
+func (d Directory) ListGroupAdmins(orgID, groupID int) ([]DirectoryGroupUser, error) {
+	return d.ListGroupAdminsContext(context.Background(), orgID, groupID)
+}
+
+// ListGroupAdminsContext ...
+func (d Directory) ListGroupAdminsContext(ctx context.Context, orgID, groupID int) ([]DirectoryGroupUser, error) {
+	group, err := d.GetGroupContext(ctx, orgID, groupID, Parameters{"fields": []string{"id", "admins"}})
+	return group.Admins, err
+}
+
+// GetGroupAuthor returns the user who created the group
+func (d Directory) GetGroupAuthor(orgID, groupID int) (DirectoryGroupUser, error) {
+	return d.GetGroupAuthorContext(context.Background(), orgID, groupID)
+}
+
+// GetGroupAuthorContext ...
+func (d Directory) GetGroupAuthorContext(ctx context.Context, orgID, groupID int) (DirectoryGroupUser, error) {
+	group, err := d.GetGroupContext(ctx, orgID, groupID, Parameters{"fields": []string{"id", "author"}})
+	return group.Author, err
+}
+
+// SetGroupAdmins replaces the whole admin list of the group,
+// a *NotFoundError is returned for the first user that does not exist
+func (d Directory) SetGroupAdmins(orgID, groupID int, userIDs []int) (DirectoryGroup, error) {
+	return d.SetGroupAdminsContext(context.Background(), orgID, groupID, userIDs)
+}
+
+// SetGroupAdminsContext ...
+func (d Directory) SetGroupAdminsContext(ctx context.Context, orgID, groupID int, userIDs []int) (DirectoryGroup, error) {
+	for _, id := range userIDs {
+		if err := d.checkUser(ctx, orgID, id); err != nil {
+			return DirectoryGroup{}, err
+		}
+	}
+	return d.setGroupAdmins(ctx, orgID, groupID, userIDs)
+}
+
+// setGroupAdmins replaces the admin list without checking the users,
+// admins read back from the API are sent as they are
+func (d Directory) setGroupAdmins(ctx context.Context, orgID, groupID int, userIDs []int) (DirectoryGroup, error) {
+	// an empty list is sent to clear the admins
+	admins := make([]DirectoryGroupAdmin, 0, len(userIDs))
+	for _, id := range userIDs {
+		admins = append(admins, DirectoryGroupAdmin{ID: id})
+	}
+	return d.PatchGroupContext(ctx, orgID, groupID, DirectoryGroupPatch{Admins: &admins})
+}
+
+// AddGroupAdmin makes the user an admin of the group,
+// a *NotFoundError is returned if there is no such user.
+// The admin list is read, changed and sent back whole,
+// so concurrent changes of the same group may undo each other.
+func (d Directory) AddGroupAdmin(orgID, groupID, userID int) (DirectoryGroup, error) {
+	return d.AddGroupAdminContext(context.Background(), orgID, groupID, userID)
+}
+
+// AddGroupAdminContext ...
+func (d Directory) AddGroupAdminContext(ctx context.Context, orgID, groupID, userID int) (DirectoryGroup, error) {
+	if err := d.checkUser(ctx, orgID, userID); err != nil {
+		return DirectoryGroup{}, err
+	}
+	ids, err := d.groupAdminIDs(ctx, orgID, groupID)
+	if err != nil {
+		return DirectoryGroup{}, err
+	}
+	for _, id := range ids {
+		if id == userID {
+			return d.GetGroupContext(ctx, orgID, groupID, nil)
+		}
+	}
+	return d.setGroupAdmins(ctx, orgID, groupID, append(ids, userID))
+}
+
+// RemoveGroupAdmin revokes the user's admin rights on the group,
+// a *NotFoundError is returned if the user is not an admin of the group.
+// Like AddGroupAdmin it sends the whole admin list back,
+// so concurrent changes of the same group may undo each other.
+func (d Directory) RemoveGroupAdmin(orgID, groupID, userID int) (DirectoryGroup, error) {
+	return d.RemoveGroupAdminContext(context.Background(), orgID, groupID, userID)
+}
+
+// RemoveGroupAdminContext ...
+func (d Directory) RemoveGroupAdminContext(ctx context.Context, orgID, groupID, userID int) (DirectoryGroup, error) {
+	ids, err := d.groupAdminIDs(ctx, orgID, groupID)
+	if err != nil {
+		return DirectoryGroup{}, err
+	}
+	keep := ids[:0]
+	for _, id := range ids {
+		if id != userID {
+			keep = append(keep, id)
+		}
+	}
+	if len(keep) == len(ids) {
+		return DirectoryGroup{}, &NotFoundError{Kind: "group admin", ID: userID}
+	}
+	return d.setGroupAdmins(ctx, orgID, groupID, keep)
+}
+
+func (d Directory) groupAdminIDs(ctx context.Context, orgID, groupID int) ([]int, error) {
+	admins, err := d.ListGroupAdminsContext(ctx, orgID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(admins))
+	for _, a := range admins {
+		ids = append(ids, a.ID)
+	}
+	return ids, nil
+}
+
+// checkUser returns a *NotFoundError if there is no such user
+func (d Directory) checkUser(ctx context.Context, orgID, userID int) error {
+	_, err := d.GetUserContext(ctx, orgID, userID, Parameters{"fields": []string{"id"}})
+	if IsNotFound(err) {
+		err = &NotFoundError{Kind: "user", ID: userID, Err: err}
+	}
+	return err
+}
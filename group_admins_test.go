@@ -0,0 +1,62 @@
+package go_yapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupAdmins(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/groups/", newFakeGroupsHandler(t))
+	mux.HandleFunc("/users/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/10/", "/users/11/":
+			fmt.Fprint(w, `{"id": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithDefaultOrgID(1))
+
+	group, err := d.CreateGroup(0, DirectoryNewGroup{Name: "Team", Admins: []DirectoryGroupAdmin{{ID: 10}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = d.AddGroupAdmin(0, group.ID, 11); err != nil {
+		t.Fatal(err)
+	}
+	admins, err := d.ListGroupAdmins(0, group.ID)
+	if err != nil || len(admins) != 2 || admins[1].ID != 11 {
+		t.Errorf("need admins 10, 11 but got %v, '%v'", admins, err)
+	}
+
+	_, err = d.AddGroupAdmin(0, group.ID, 404)
+	var nf *NotFoundError
+	if !errors.As(err, &nf) || nf.Kind != "user" || nf.ID != 404 || !IsStatus(err, http.StatusNotFound) {
+		t.Errorf("need user not found error but got '%v'", err)
+	}
+
+	_, err = d.SetGroupAdmins(0, group.ID, []int{11, 405})
+	if !errors.As(err, &nf) || nf.Kind != "user" || nf.ID != 405 {
+		t.Errorf("need user not found error from set but got '%v'", err)
+	}
+	if admins, _ = d.ListGroupAdmins(0, group.ID); len(admins) != 2 {
+		t.Errorf("failed set must not change admins but got %v", admins)
+	}
+
+	if _, err = d.RemoveGroupAdmin(0, group.ID, 10); err != nil {
+		t.Fatal(err)
+	}
+	if admins, _ = d.ListGroupAdmins(0, group.ID); len(admins) != 1 || admins[0].ID != 11 {
+		t.Errorf("need admin 11 but got %v", admins)
+	}
+	if _, err = d.RemoveGroupAdmin(0, group.ID, 10); !IsNotFound(err) {
+		t.Errorf("need not found for removed admin but got '%v'", err)
+	}
+}
@@ -0,0 +1,187 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// MemberType is the kind of a group member
+type MemberType string
+
+const (
+	MemberUser       MemberType = "user"
+	MemberGroup      MemberType = "group"
+	MemberDepartment MemberType = "department"
+)
+
+// DirectoryGroupMember is a member of a group: a user, a group or a department.
+// Only Type and ID are sent to the API, the object of the matching type is filled
+// when the member is read from the API.
+type DirectoryGroupMember struct {
+	Type MemberType
+	ID   int
+
+	User       *DirectoryGroupUser
+	Group      *DirectoryGroup
+	Department *DirectoryDepartment
+}
+
+// UserMember ...
+func UserMember(userID int) DirectoryGroupMember {
+	return DirectoryGroupMember{Type: MemberUser, ID: userID}
+}
+
+// GroupMember ...
+func GroupMember(groupID int) DirectoryGroupMember {
+	return DirectoryGroupMember{Type: MemberGroup, ID: groupID}
+}
+
+// DepartmentMember ...
+func DepartmentMember(depID int) DirectoryGroupMember {
+	return DirectoryGroupMember{Type: MemberDepartment, ID: depID}
+}
+
+type groupMemberJSON struct {
+	Type   MemberType      `json:"type"`
+	ID     int             `json:"id,omitempty"`
+	Object json.RawMessage `json:"object,omitempty"`
+}
+
+// MarshalJSON encodes the member as {"type": ..., "id": ...} the API expects in requests
+func (m DirectoryGroupMember) MarshalJSON() ([]byte, error) {
+	return json.Marshal(groupMemberJSON{Type: m.Type, ID: m.ID})
+}
+
+// UnmarshalJSON decodes both {"type": ..., "id": ...} and {"type": ..., "object": {...}}
+func (m *DirectoryGroupMember) UnmarshalJSON(b []byte) error {
+	var v groupMemberJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*m = DirectoryGroupMember{Type: v.Type, ID: v.ID}
+	if len(v.Object) == 0 || string(v.Object) == "null" {
+		return nil
+	}
+	var id directoryID
+	if err := json.Unmarshal(v.Object, &id); err != nil {
+		return err
+	}
+	m.ID = id.ID
+	switch v.Type {
+	case MemberUser:
+		m.User = &DirectoryGroupUser{}
+		return decodeMemberObject(v.Object, m.User)
+	case MemberGroup:
+		m.Group = &DirectoryGroup{}
+		return decodeMemberObject(v.Object, m.Group)
+	case MemberDepartment:
+		m.Department = &DirectoryDepartment{}
+		return decodeMemberObject(v.Object, m.Department)
+	}
+	return nil
+}
+
+// decodeMemberObject decodes the member object leniently: a field of an unexpected type,
+// e.g. a numeric external_id, is left empty instead of failing the whole response
+func decodeMemberObject(b []byte, v interface{}) error {
+	err := json.Unmarshal(b, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return nil
+	}
+	return err
+}
+
+// ListGroupMembers ...
+func (d Directory) ListGroupMembers(orgID, groupID int) ([]DirectoryGroupMember, error) {
+	return d.ListGroupMembersContext(context.Background(), orgID, groupID)
+}
+
+// ListGroupMembersContext ...
+func (d Directory) ListGroupMembersContext(ctx context.Context, orgID, groupID int) ([]DirectoryGroupMember, error) {
+	return get[[]DirectoryGroupMember](ctx, d, d.endpoint("/groups/{id}/members/", groupID), orgID, nil)
+}
+
+// AddGroupMember ...
+func (d Directory) AddGroupMember(orgID, groupID int, member DirectoryGroupMember) (DirectoryGroupMember, error) {
+	return d.AddGroupMemberContext(context.Background(), orgID, groupID, member)
+}
+
+// AddGroupMemberContext ...
+func (d Directory) AddGroupMemberContext(ctx context.Context, orgID, groupID int, member DirectoryGroupMember) (DirectoryGroupMember, error) {
+	var added DirectoryGroupMember
+	j, err := json.Marshal(member)
+	if err != nil {
+		return added, err
+	}
+	err = d.post(
+		ctx,
+		d.endpoint("/groups/{id}/members/", groupID),
+		nil,
+		orgID,
+		j,
+		&added,
+	)
+	return added, err
+}
+
+// RemoveGroupMember ...
+func (d Directory) RemoveGroupMember(orgID, groupID int, member DirectoryGroupMember) error {
+	return d.RemoveGroupMemberContext(context.Background(), orgID, groupID, member)
+}
+
+// RemoveGroupMemberContext ...
+func (d Directory) RemoveGroupMemberContext(ctx context.Context, orgID, groupID int, member DirectoryGroupMember) error {
+	return d.UpdateGroupMembersContext(ctx, orgID, groupID, nil, []DirectoryGroupMember{member})
+}
+
+type groupMemberOperation struct {
+	OperationType string               `json:"operation_type"` // <add|remove>
+	Value         DirectoryGroupMember `json:"value"`
+}
+
+// UpdateGroupMembers adds and removes members in one call
+func (d Directory) UpdateGroupMembers(orgID, groupID int, add, remove []DirectoryGroupMember) error {
+	return d.UpdateGroupMembersContext(context.Background(), orgID, groupID, add, remove)
+}
+
+// UpdateGroupMembersContext ...
+func (d Directory) UpdateGroupMembersContext(ctx context.Context, orgID, groupID int, add, remove []DirectoryGroupMember) error {
+	ops := make([]groupMemberOperation, 0, len(add)+len(remove))
+	for _, m := range add {
+		ops = append(ops, groupMemberOperation{OperationType: "add", Value: m})
+	}
+	for _, m := range remove {
+		ops = append(ops, groupMemberOperation{OperationType: "remove", Value: m})
+	}
+	j, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	return d.request(
+		ctx,
+		http.MethodPost,
+		d.endpoint("/groups/{id}/members/bulk-update/", groupID),
+		nil,
+		orgID,
+		http.StatusOK,
+		j,
+		nil,
+	)
+}
+
+// SetGroupMembers replaces the whole member list of the group
+func (d Directory) SetGroupMembers(orgID, groupID int, members []DirectoryGroupMember) (DirectoryGroup, error) {
+	return d.SetGroupMembersContext(context.Background(), orgID, groupID, members)
+}
+
+// SetGroupMembersContext ...
+func (d Directory) SetGroupMembersContext(ctx context.Context, orgID, groupID int, members []DirectoryGroupMember) (DirectoryGroup, error) {
+	// an empty list is sent to clear the members
+	if members == nil {
+		members = []DirectoryGroupMember{}
+	}
+	return d.PatchGroupContext(ctx, orgID, groupID, DirectoryGroupPatch{Members: &members})
+}
@@ -0,0 +1,95 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupMemberJSON(t *testing.T) {
+	var members []DirectoryGroupMember
+	err := json.Unmarshal([]byte(`[
+		{"type": "user", "object": {"id": 5, "nickname": "ivan"}},
+		{"type": "department", "object": {"id": 2, "name": "Sales"}},
+		{"type": "group", "id": 9}
+	]`), &members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members[0].ID != 5 || members[0].User.Nickname != "ivan" ||
+		members[1].ID != 2 || members[1].Department.Name != "Sales" ||
+		members[2].ID != 9 || members[2].Type != MemberGroup {
+		t.Errorf("wrong members %+v", members)
+	}
+
+	b, _ := json.Marshal(members[0])
+	if string(b) != `{"type":"user","id":5}` {
+		t.Errorf("member encoded as %s", b)
+	}
+}
+
+func TestGroupMemberNumericExternalID(t *testing.T) {
+	var group DirectoryGroup
+	err := json.Unmarshal([]byte(`{"id": 1, "members": [
+		{"type": "user", "object": {"id": 5, "nickname": "ivan", "external_id": 123}},
+		{"type": "group", "object": {"id": 6, "name": "sales", "external_id": 456}}
+	]}`), &group)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Members) != 2 || group.Members[0].ID != 5 || group.Members[0].User.Nickname != "ivan" || group.Members[1].ID != 6 {
+		t.Errorf("got members %+v", group.Members)
+	}
+
+	var m DirectoryGroupMember
+	if err := json.Unmarshal([]byte(`{"type": "user", "object": {"id": 5,}}`), &m); err == nil {
+		t.Error("need error for malformed json")
+	}
+}
+
+func TestGroupMembers(t *testing.T) {
+	var got []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = append(got, r.Method+" "+r.URL.Path+" "+string(body))
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `[{"type": "user", "object": {"id": 5}}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/groups/3/members/":
+			w.WriteHeader(http.StatusCreated)
+			w.Write(body)
+		case r.Method == http.MethodPost:
+			fmt.Fprint(w, `[]`)
+		default:
+			fmt.Fprint(w, `{"id": 3}`)
+		}
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if members, err := d.ListGroupMembers(1, 3); err != nil || len(members) != 1 || members[0].ID != 5 {
+		t.Errorf("list: %v, '%v'", members, err)
+	}
+	if m, err := d.AddGroupMember(1, 3, GroupMember(4)); err != nil || m.ID != 4 {
+		t.Errorf("add: %v, '%v'", m, err)
+	}
+	if err := d.RemoveGroupMember(1, 3, UserMember(5)); err != nil {
+		t.Errorf("remove: '%v'", err)
+	}
+	if _, err := d.SetGroupMembers(1, 3, nil); err != nil {
+		t.Errorf("set: '%v'", err)
+	}
+
+	need := []string{
+		`GET /groups/3/members/ `,
+		`POST /groups/3/members/ {"type":"group","id":4}`,
+		`POST /groups/3/members/bulk-update/ [{"operation_type":"remove","value":{"type":"user","id":5}}]`,
+		`PATCH /groups/3/ {"members":[]}`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(need) {
+		t.Errorf("got requests\n%v\nneed\n%v", got, need)
+	}
+}
@@ -0,0 +1,154 @@
+package go_yapi
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter throttles API calls on the client side.
+// A token bucket limits the rate of calls and a semaphore limits calls in flight.
+// One Limiter is shared by all methods of the Directory it is given to,
+// and may be shared by several Directory values to respect a common quota.
+type Limiter struct {
+	rate  float64 // tokens per second, zero means unlimited
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	sem chan struct{}
+
+	statsMu sync.Mutex
+	stats   LimiterStats
+}
+
+// LimiterStats reports how much calls were throttled
+type LimiterStats struct {
+	// Calls is the number of calls passed through the limiter
+	Calls int64
+	// Throttled is the number of calls which had to wait
+	Throttled int64
+	// Wait is the total time calls waited for a token or a free slot
+	Wait time.Duration
+}
+
+// NewLimiter returns a limiter allowing rps calls per second with bursts up to burst calls
+// and at most maxInFlight concurrent calls. Zero rps or maxInFlight disables that limit.
+func NewLimiter(rps float64, burst, maxInFlight int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &Limiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+	if maxInFlight > 0 {
+		l.sem = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// Wait blocks until the call is allowed or ctx is done.
+// On success the returned release func must be called when the call is finished.
+func (l *Limiter) Wait(ctx context.Context) (release func(), waited time.Duration, err error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+	start := time.Now()
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			l.record(time.Since(start))
+			return nil, time.Since(start), ctx.Err()
+		}
+	}
+	release = func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	// the token is taken once the slot is ours, start may be long ago
+	if d := l.reserve(time.Now()); d > 0 {
+		if err = sleepContext(ctx, d); err != nil {
+			l.cancel()
+			release()
+			l.record(time.Since(start))
+			return nil, time.Since(start), err
+		}
+	}
+
+	waited = time.Since(start)
+	l.record(waited)
+	return release, waited, nil
+}
+
+func (l *Limiter) interceptor() Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			release, waited, err := l.Wait(ctx)
+			call.Throttled = waited
+			if err != nil {
+				return err
+			}
+			defer release()
+			return next(ctx, call)
+		}
+	}
+}
+
+// Stats returns the throttling statistics since the limiter was created
+func (l *Limiter) Stats() LimiterStats {
+	if l == nil {
+		return LimiterStats{}
+	}
+	l.statsMu.Lock()
+	defer l.statsMu.Unlock()
+	return l.stats
+}
+
+// reserve takes a token and returns how long to wait until it is available
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used
+func (l *Limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tokens < l.burst {
+		l.tokens++
+	}
+}
+
+func (l *Limiter) record(wait time.Duration) {
+	l.statsMu.Lock()
+	defer l.statsMu.Unlock()
+	l.stats.Calls++
+	// a few microseconds are spent even when nothing blocks
+	if wait > time.Millisecond {
+		l.stats.Throttled++
+		l.stats.Wait += wait
+	}
+}
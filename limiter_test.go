@@ -0,0 +1,85 @@
+package go_yapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(100, 2, 0)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		release, _, err := l.Wait(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// 2 calls pass with the burst, 4 more need 40ms
+	if d := time.Since(start); d < 30*time.Millisecond {
+		t.Errorf("6 calls at 100 rps with burst 2 took only %v", d)
+	}
+	if s := l.Stats(); s.Calls != 6 || s.Throttled == 0 || s.Wait == 0 {
+		t.Errorf("wrong stats %+v", s)
+	}
+}
+
+func TestLimiterInFlight(t *testing.T) {
+	l := NewLimiter(0, 0, 1)
+	release, _, err := l.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err = l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("need deadline exceeded while slot is busy but got '%v'", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r, waited, err := l.Wait(context.Background())
+		if err != nil || waited < 10*time.Millisecond {
+			t.Errorf("need to wait for the slot but waited %v, '%v'", waited, err)
+			return
+		}
+		r()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	release()
+	wg.Wait()
+}
+
+func TestLimiterRateAndInFlight(t *testing.T) {
+	l := NewLimiter(10, 1, 1)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, _, err := l.Wait(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			time.Sleep(150 * time.Millisecond)
+			release()
+		}()
+	}
+	wg.Wait()
+	// the calls are serialized by the slot and each one is longer than the 100ms between tokens
+	if d := time.Since(start); d > 1200*time.Millisecond {
+		t.Errorf("5 serialized 150ms calls at 10 rps took %v", d)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tokens < 0 {
+		t.Errorf("tokens went negative: %v", l.tokens)
+	}
+}
@@ -0,0 +1,117 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// WithLogger logs every call of the Directory: method, URL, org ID, status, duration and sizes.
+// Failed calls are logged at warning level, others at info level.
+// Headers and bodies are logged only when the debug level is enabled,
+// the Authorization header and password fields are always redacted.
+func WithLogger(logger *slog.Logger) DirectoryOption {
+	return func(d *Directory) {
+		d.logger = logger
+	}
+}
+
+// LoggingInterceptor logs every round trip to logger the same way as WithLogger
+func LoggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+			logCall(ctx, logger, call, err)
+			return err
+		}
+	}
+}
+
+func logCall(ctx context.Context, logger *slog.Logger, call *Call, err error) {
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("method", call.Request.Method),
+		slog.String("url", call.Request.URL.String()),
+		slog.Int("org_id", call.OrgID),
+		slog.Int("attempt", call.Attempt),
+		slog.Duration("duration", call.Duration),
+		slog.Int("request_bytes", len(call.RequestBody)),
+		slog.Int("response_bytes", len(call.ResponseBody)),
+	}
+	if call.Response != nil {
+		attrs = append(attrs, slog.Int("status", call.Response.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs,
+			slog.Any("request_header", redactHeader(call.Request.Header)),
+			slog.String("request_body", redactBody(call.RequestBody)),
+			slog.String("response_body", redactBody(call.ResponseBody)),
+		)
+	}
+	logger.LogAttrs(ctx, level, "directory call", attrs...)
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for k := range h {
+		if strings.EqualFold(k, "Authorization") {
+			h[k] = []string{redacted}
+		}
+	}
+	return h
+}
+
+// redactBody hides values of password fields in a JSON body
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		if strings.Contains(strings.ToLower(string(body)), "password") {
+			return redacted
+		}
+		return string(body)
+	}
+	if !redactValue(v) {
+		return string(body)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return redacted
+	}
+	return string(b)
+}
+
+// redactValue replaces password fields in place and reports whether something was replaced
+func redactValue(v interface{}) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k := range v {
+			if strings.Contains(strings.ToLower(k), "password") {
+				v[k] = redacted
+				found = true
+			} else if redactValue(v[k]) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if redactValue(v[i]) {
+				found = true
+			}
+		}
+	}
+	return found
+}
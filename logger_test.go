@@ -0,0 +1,72 @@
+package go_yapi
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 3, "nickname": "test"}`)
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithLogger(logger))
+
+	user := DirectoryUser{Nickname: "test", Password: "secret100500"}
+	if err := d.CreateUser(12, &user); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, need := range []string{"method=POST", "org_id=12", "status=201", "response_bytes=29", redacted, "nickname"} {
+		if !strings.Contains(out, need) {
+			t.Errorf("log has no '%s': %s", need, out)
+		}
+	}
+	if strings.Contains(out, "secret100500") {
+		t.Errorf("password is logged: %s", out)
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	h := http.Header{"Authorization": []string{"OAuth token"}, "X-Org-Id": []string{"1"}}
+	r := redactHeader(h)
+	if r.Get("Authorization") != redacted || r.Get("X-Org-Id") != "1" || h.Get("Authorization") != "OAuth token" {
+		t.Errorf("wrong redaction %v of %v", r, h)
+	}
+}
+
+func TestDebugLogsErrorBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code": "invalid", "password": "secret"}`)
+	}))
+	defer srv.Close()
+
+	buf := &bytes.Buffer{}
+	log.SetOutput(buf)
+	Debug = true
+	defer func() {
+		log.SetOutput(os.Stderr)
+		Debug = false
+	}()
+
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+	if _, err := d.GetUser(1, 2, nil); err == nil {
+		t.Fatal("need an error")
+	}
+	if out := buf.String(); !strings.Contains(out, `"invalid"`) || strings.Contains(out, "secret") {
+		t.Errorf("need redacted body logged but got %q", out)
+	}
+}
@@ -0,0 +1,250 @@
+package go_yapi
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are upper bounds in seconds of the latency histogram
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics counts API calls by endpoint template, method and status,
+// with latency histograms and retry/throttle counters.
+// It is exposed with Publish (expvar) and as http.Handler in Prometheus text format.
+type Metrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[metricKey]int64
+	latencies map[metricKey]*histogram
+	retries   map[metricKey]int64
+	throttled map[metricKey]*throttle
+}
+
+type metricKey struct {
+	Endpoint string
+	Method   string
+	Status   string
+}
+
+type histogram struct {
+	counts []int64 // per bucket, not cumulative, the last one is +Inf
+	sum    float64
+	count  int64
+}
+
+type throttle struct {
+	count   int64
+	seconds float64
+}
+
+// NewMetrics returns metrics with latency histogram buckets in seconds, DefaultLatencyBuckets if none
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets:   buckets,
+		requests:  map[metricKey]int64{},
+		latencies: map[metricKey]*histogram{},
+		retries:   map[metricKey]int64{},
+		throttled: map[metricKey]*throttle{},
+	}
+}
+
+// WithMetrics records every attempt of every call of the Directory to m
+func WithMetrics(m *Metrics) DirectoryOption {
+	return WithInterceptors(MetricsInterceptor(m))
+}
+
+// ObserveCall implements MetricsRecorder
+func (m *Metrics) ObserveCall(_ context.Context, call *Call, err error) {
+	endpoint := call.Endpoint
+	if endpoint == "" {
+		endpoint = call.Request.URL.Path
+	}
+	status := "error"
+	if call.Response != nil {
+		status = strconv.Itoa(call.Response.StatusCode)
+	}
+	key := metricKey{Endpoint: endpoint, Method: call.Request.Method}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[metricKey{Endpoint: endpoint, Method: call.Request.Method, Status: status}]++
+
+	if call.Response != nil {
+		h := m.latencies[key]
+		if h == nil {
+			h = &histogram{counts: make([]int64, len(m.buckets)+1)}
+			m.latencies[key] = h
+		}
+		s := call.Duration.Seconds()
+		i := sort.SearchFloat64s(m.buckets, s)
+		h.counts[i]++
+		h.sum += s
+		h.count++
+	}
+
+	if call.Attempt > 1 {
+		m.retries[key]++
+	}
+
+	if call.Throttled > time.Millisecond {
+		t := m.throttled[key]
+		if t == nil {
+			t = &throttle{}
+			m.throttled[key] = t
+		}
+		t.count++
+		t.seconds += call.Throttled.Seconds()
+	}
+}
+
+// Publish exposes the metrics as expvar variable name, it panics if the name is already used
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(m.snapshot))
+}
+
+// metricsSnapshot is the expvar view of Metrics
+type metricsSnapshot struct {
+	Requests  []metricValue `json:"requests"`
+	Latencies []metricValue `json:"latencies"`
+	Retries   []metricValue `json:"retries"`
+	Throttled []metricValue `json:"throttled"`
+}
+
+type metricValue struct {
+	Endpoint string    `json:"endpoint"`
+	Method   string    `json:"method"`
+	Status   string    `json:"status,omitempty"`
+	Count    int64     `json:"count"`
+	Seconds  float64   `json:"seconds,omitempty"`
+	Buckets  []float64 `json:"buckets,omitempty"`
+	Counts   []int64   `json:"counts,omitempty"`
+}
+
+func (m *Metrics) snapshot() interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s := metricsSnapshot{}
+	for _, k := range sortedKeys(m.requests) {
+		s.Requests = append(s.Requests, metricValue{Endpoint: k.Endpoint, Method: k.Method, Status: k.Status, Count: m.requests[k]})
+	}
+	for _, k := range sortedKeys(m.latencies) {
+		h := m.latencies[k]
+		s.Latencies = append(s.Latencies, metricValue{
+			Endpoint: k.Endpoint,
+			Method:   k.Method,
+			Count:    h.count,
+			Seconds:  h.sum,
+			Buckets:  m.buckets,
+			Counts:   append([]int64(nil), h.counts...),
+		})
+	}
+	for _, k := range sortedKeys(m.retries) {
+		s.Retries = append(s.Retries, metricValue{Endpoint: k.Endpoint, Method: k.Method, Count: m.retries[k]})
+	}
+	for _, k := range sortedKeys(m.throttled) {
+		t := m.throttled[k]
+		s.Throttled = append(s.Throttled, metricValue{Endpoint: k.Endpoint, Method: k.Method, Count: t.count, Seconds: t.seconds})
+	}
+	return s
+}
+
+// ServeHTTP writes the metrics in Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus writes the metrics in Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b := &strings.Builder{}
+
+	b.WriteString("# HELP yapi_requests_total API calls by endpoint, method and status.\n")
+	b.WriteString("# TYPE yapi_requests_total counter\n")
+	for _, k := range sortedKeys(m.requests) {
+		fmt.Fprintf(b, "yapi_requests_total{%s,status=\"%s\"} %d\n", k.labels(), escapeLabel(k.Status), m.requests[k])
+	}
+
+	b.WriteString("# HELP yapi_request_duration_seconds API call latency.\n")
+	b.WriteString("# TYPE yapi_request_duration_seconds histogram\n")
+	for _, k := range sortedKeys(m.latencies) {
+		h := m.latencies[k]
+		var cumulative int64
+		for i, le := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(b, "yapi_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", k.labels(), formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(b, "yapi_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", k.labels(), h.count)
+		fmt.Fprintf(b, "yapi_request_duration_seconds_sum{%s} %s\n", k.labels(), formatFloat(h.sum))
+		fmt.Fprintf(b, "yapi_request_duration_seconds_count{%s} %d\n", k.labels(), h.count)
+	}
+
+	b.WriteString("# HELP yapi_retries_total Repeated attempts of API calls.\n")
+	b.WriteString("# TYPE yapi_retries_total counter\n")
+	for _, k := range sortedKeys(m.retries) {
+		fmt.Fprintf(b, "yapi_retries_total{%s} %d\n", k.labels(), m.retries[k])
+	}
+
+	b.WriteString("# HELP yapi_throttled_total API calls delayed by the client side limiter.\n")
+	b.WriteString("# TYPE yapi_throttled_total counter\n")
+	for _, k := range sortedKeys(m.throttled) {
+		fmt.Fprintf(b, "yapi_throttled_total{%s} %d\n", k.labels(), m.throttled[k].count)
+	}
+	b.WriteString("# HELP yapi_throttled_seconds_total Time API calls waited for the client side limiter.\n")
+	b.WriteString("# TYPE yapi_throttled_seconds_total counter\n")
+	for _, k := range sortedKeys(m.throttled) {
+		fmt.Fprintf(b, "yapi_throttled_seconds_total{%s} %s\n", k.labels(), formatFloat(m.throttled[k].seconds))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (k metricKey) labels() string {
+	return "endpoint=\"" + escapeLabel(k.Endpoint) + "\",method=\"" + escapeLabel(k.Method) + "\""
+}
+
+func sortedKeys[V any](m map[metricKey]V) []metricKey {
+	keys := make([]metricKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Endpoint != keys[j].Endpoint {
+			return keys[i].Endpoint < keys[j].Endpoint
+		}
+		if keys[i].Method != keys[j].Method {
+			return keys[i].Method < keys[j].Method
+		}
+		return keys[i].Status < keys[j].Status
+	})
+	return keys
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,57 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	defer srv.Close()
+
+	m := NewMetrics(0.5, 1)
+	d := NewDirectory(srv.Client(),
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithMetrics(m),
+	)
+	if _, err := d.GetUser(1, 15, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetUser(1, 16, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+	for _, need := range []string{
+		`yapi_requests_total{endpoint="/users/{id}/",method="GET",status="200"} 2`,
+		`yapi_requests_total{endpoint="/users/{id}/",method="GET",status="502"} 1`,
+		`yapi_request_duration_seconds_bucket{endpoint="/users/{id}/",method="GET",le="+Inf"} 3`,
+		`yapi_request_duration_seconds_count{endpoint="/users/{id}/",method="GET"} 3`,
+		`yapi_retries_total{endpoint="/users/{id}/",method="GET"} 1`,
+	} {
+		if !strings.Contains(out, need) {
+			t.Errorf("no '%s' in\n%s", need, out)
+		}
+	}
+
+	b, err := json.Marshal(m.snapshot())
+	if err != nil || !strings.Contains(string(b), `"endpoint":"/users/{id}/"`) {
+		t.Errorf("wrong expvar snapshot %s, '%v'", b, err)
+	}
+}
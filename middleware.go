@@ -0,0 +1,106 @@
+package go_yapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Call is one API call passed through the interceptors of a Directory
+type Call struct {
+	Request *http.Request
+	// RequestBody is the body of Request, it is sent again on every attempt
+	RequestBody    []byte
+	ExpectedStatus int
+	// Endpoint is the path template of the call, e.g. /users/{id}/
+	Endpoint string
+	OrgID    int
+	// Attempt is the number of the current attempt starting from 1
+	Attempt int
+
+	// Response and ResponseBody are set after the round trip, Response is nil on transport errors
+	Response     *http.Response
+	ResponseBody []byte
+	// Start and Duration time the last round trip
+	Start    time.Time
+	Duration time.Duration
+	// Throttled is how long the last attempt waited for the limiter
+	Throttled time.Duration
+}
+
+// RoundTrip sends the call and fills its response.
+// A status other than ExpectedStatus is returned as *APIError.
+type RoundTrip func(ctx context.Context, call *Call) error
+
+// Interceptor wraps a round trip. It may change the request before calling next,
+// inspect the response, the error and the timing after it, or not call next at all.
+type Interceptor func(next RoundTrip) RoundTrip
+
+// WithInterceptors adds interceptors to the Directory, the first one is the outermost.
+// They are called inside the retry policy, so once per attempt,
+// and outside the logger and the limiter of the Directory.
+func WithInterceptors(interceptors ...Interceptor) DirectoryOption {
+	return func(d *Directory) {
+		d.interceptors = append(d.interceptors, interceptors...)
+	}
+}
+
+// chain wraps rt with interceptors, the first one is the outermost
+func chain(rt RoundTrip, interceptors ...Interceptor) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if interceptors[i] != nil {
+			rt = interceptors[i](rt)
+		}
+	}
+	return rt
+}
+
+// transport is the innermost round trip of a chain
+func transport(client *http.Client) RoundTrip {
+	return func(ctx context.Context, call *Call) error {
+		call.Start = time.Now()
+		resp, body, err := roundTrip(client, call.Request, call.ExpectedStatus)
+		call.Response, call.ResponseBody, call.Duration = resp, body, time.Since(call.Start)
+		return err
+	}
+}
+
+// rewind prepares the call to be sent once more
+func (c *Call) rewind(ctx context.Context) error {
+	req := c.Request.Clone(ctx)
+	if c.Request.GetBody != nil {
+		body, err := c.Request.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+	}
+	c.Request = req
+	c.Response, c.ResponseBody = nil, nil
+	c.Duration, c.Throttled = 0, 0
+	return nil
+}
+
+// MetricsRecorder receives every round trip passed through MetricsInterceptor
+type MetricsRecorder interface {
+	ObserveCall(ctx context.Context, call *Call, err error)
+}
+
+// MetricsRecorderFunc is an adapter to use a function as MetricsRecorder
+type MetricsRecorderFunc func(ctx context.Context, call *Call, err error)
+
+// ObserveCall calls f(ctx, call, err)
+func (f MetricsRecorderFunc) ObserveCall(ctx context.Context, call *Call, err error) {
+	f(ctx, call, err)
+}
+
+// MetricsInterceptor passes every round trip to recorder after it is finished
+func MetricsInterceptor(recorder MetricsRecorder) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			err := next(ctx, call)
+			recorder.ObserveCall(ctx, call, err)
+			return err
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package go_yapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInterceptors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-ID") != "test" {
+			t.Errorf("interceptor header not sent")
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	defer srv.Close()
+
+	var order []string
+	header := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			order = append(order, "header")
+			call.Request.Header.Set("X-Request-ID", "test")
+			return next(ctx, call)
+		}
+	}
+	// fault injects 503 into the first attempt
+	fault := func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			order = append(order, "fault")
+			if call.Attempt == 1 {
+				return &APIError{StatusCode: http.StatusServiceUnavailable}
+			}
+			return next(ctx, call)
+		}
+	}
+	var observed []error
+	metrics := MetricsInterceptor(MetricsRecorderFunc(func(ctx context.Context, call *Call, err error) {
+		observed = append(observed, err)
+	}))
+
+	d := NewDirectory(srv.Client(),
+		WithBaseURL(srv.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithInterceptors(metrics, header, fault),
+	)
+	if _, err := d.GetGroup(1, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(order) != "[header fault header fault]" {
+		t.Errorf("wrong order of interceptors %v", order)
+	}
+	if len(observed) != 2 || !IsStatus(observed[0], http.StatusServiceUnavailable) || observed[1] != nil {
+		t.Errorf("metrics must see every attempt but got %v", observed)
+	}
+}
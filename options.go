@@ -0,0 +1,125 @@
+package go_yapi
+
+import (
+	"context"
+	"strconv"
+)
+
+// ListUsersOptions are typed query parameters of GetUsers, zero fields are not sent
+type ListUsersOptions struct {
+	Fields       []string
+	Page         int
+	PerPage      int
+	DepartmentID int
+	GroupID      int
+	Nickname     string
+	// IsDismissed filters dismissed (true) or working (false) users, nil lists both
+	IsDismissed *bool
+	Ordering    string
+	// Extra parameters are added as is, it is an escape hatch for parameters without a field
+	Extra Parameters
+}
+
+// Parameters encodes the options to the query the API expects
+func (o ListUsersOptions) Parameters() Parameters {
+	p := listParameters(o.Fields, o.Page, o.PerPage, o.Ordering, o.Extra)
+	setInt(p, "department_id", o.DepartmentID)
+	setInt(p, "group_id", o.GroupID)
+	setString(p, "nickname", o.Nickname)
+	if o.IsDismissed != nil {
+		p["is_dismissed"] = []string{strconv.FormatBool(*o.IsDismissed)}
+	}
+	return p
+}
+
+// ListDepartmentsOptions are typed query parameters of GetDepartments, zero fields are not sent
+type ListDepartmentsOptions struct {
+	Fields   []string
+	Page     int
+	PerPage  int
+	ParentID int
+	Ordering string
+	// Extra parameters are added as is, it is an escape hatch for parameters without a field
+	Extra Parameters
+}
+
+// Parameters encodes the options to the query the API expects
+func (o ListDepartmentsOptions) Parameters() Parameters {
+	p := listParameters(o.Fields, o.Page, o.PerPage, o.Ordering, o.Extra)
+	setInt(p, "parent_id", o.ParentID)
+	return p
+}
+
+// ListGroupsOptions are typed query parameters of GetGroups, zero fields are not sent
+type ListGroupsOptions struct {
+	Fields   []string
+	Page     int
+	PerPage  int
+	Type     string // generic, department, organization_admin, ...
+	Ordering string
+	// Extra parameters are added as is, it is an escape hatch for parameters without a field
+	Extra Parameters
+}
+
+// Parameters encodes the options to the query the API expects
+func (o ListGroupsOptions) Parameters() Parameters {
+	p := listParameters(o.Fields, o.Page, o.PerPage, o.Ordering, o.Extra)
+	setString(p, "type", o.Type)
+	return p
+}
+
+// ListUsers ...
+func (d Directory) ListUsers(orgID int, opts ListUsersOptions) (DirectoryUsers, error) {
+	return d.ListUsersContext(context.Background(), orgID, opts)
+}
+
+// ListUsersContext ...
+func (d Directory) ListUsersContext(ctx context.Context, orgID int, opts ListUsersOptions) (DirectoryUsers, error) {
+	return d.GetUsersContext(ctx, orgID, opts.Parameters())
+}
+
+// ListDepartments ...
+func (d Directory) ListDepartments(orgID int, opts ListDepartmentsOptions) (DirectoryDepartments, error) {
+	return d.ListDepartmentsContext(context.Background(), orgID, opts)
+}
+
+// ListDepartmentsContext ...
+func (d Directory) ListDepartmentsContext(ctx context.Context, orgID int, opts ListDepartmentsOptions) (DirectoryDepartments, error) {
+	return d.GetDepartmentsContext(ctx, orgID, opts.Parameters())
+}
+
+// ListGroups ...
+func (d Directory) ListGroups(orgID int, opts ListGroupsOptions) (DirectoryGroups, error) {
+	return d.ListGroupsContext(context.Background(), orgID, opts)
+}
+
+// ListGroupsContext ...
+func (d Directory) ListGroupsContext(ctx context.Context, orgID int, opts ListGroupsOptions) (DirectoryGroups, error) {
+	return d.GetGroupsContext(ctx, orgID, opts.Parameters())
+}
+
+func listParameters(fields []string, page, perPage int, ordering string, extra Parameters) Parameters {
+	p := Parameters{}
+	for k, v := range extra {
+		p[k] = append([]string(nil), v...)
+	}
+	if len(fields) > 0 {
+		p["fields"] = append([]string(nil), fields...)
+	}
+	setInt(p, "page", page)
+	setInt(p, "per_page", perPage)
+	setString(p, "ordering", ordering)
+	return p
+}
+
+func setInt(p Parameters, key string, v int) {
+	if v != 0 {
+		p[key] = []string{strconv.Itoa(v)}
+	}
+}
+
+func setString(p Parameters, key, v string) {
+	if v != "" {
+		p[key] = []string{v}
+	}
+}
@@ -0,0 +1,32 @@
+package go_yapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListUsersOptions(t *testing.T) {
+	dismissed := false
+	p := ListUsersOptions{
+		Fields:      []string{"id", "email"},
+		PerPage:     1000,
+		GroupID:     7,
+		IsDismissed: &dismissed,
+		Extra:       Parameters{"recursive_department_id": []string{"3"}},
+	}.Parameters()
+
+	need := Parameters{
+		"fields":                  []string{"id", "email"},
+		"per_page":                []string{"1000"},
+		"group_id":                []string{"7"},
+		"is_dismissed":            []string{"false"},
+		"recursive_department_id": []string{"3"},
+	}
+	if !reflect.DeepEqual(p, need) {
+		t.Errorf("got %v but need %v", p, need)
+	}
+
+	if p := (ListGroupsOptions{}).Parameters(); len(p) != 0 {
+		t.Errorf("zero options must be empty but got %v", p)
+	}
+}
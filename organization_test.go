@@ -0,0 +1,45 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOrganizationsFind(t *testing.T) {
+	var orgs DirectoryOrganizations
+	err := json.Unmarshal([]byte(`{"result": [
+		{"id": 1, "name": "First", "domains": {"master": "first.ru", "display": "first.ru", "all": ["first.ru", "first.com"]}},
+		{"id": 2, "name": "Second", "domains": {"master": "second.ru", "all": ["second.ru"]}, "services": [{"slug": "mail", "ready": true}]}
+	]}`), &orgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if org, ok := orgs.Find("2"); !ok || org.Name != "Second" || !org.Services[0].Ready {
+		t.Errorf("by ID got %+v, %v", org, ok)
+	}
+	if org, ok := orgs.Find("FIRST.com"); !ok || org.ID != 1 {
+		t.Errorf("by domain got %+v, %v", org, ok)
+	}
+	if _, ok := orgs.ByID(3); ok {
+		t.Error("unknown ID is found")
+	}
+}
+
+func TestGetOrganization(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations/5/" || r.Header.Get("X-Org-ID") != "5" {
+			t.Errorf("wrong request %s org %s", r.URL.Path, r.Header.Get("X-Org-ID"))
+		}
+		fmt.Fprint(w, `{"id": 5, "name": "Fifth"}`)
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithDefaultOrgID(5))
+
+	if org, err := d.GetOrganization(0, nil); err != nil || org.Name != "Fifth" {
+		t.Errorf("got %+v, '%v'", org, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Package otelyapi adapts an OpenTelemetry tracer to go-yapi Tracer.
+//
+//	directory := yapi.NewDirectory(client, yapi.WithTracer(otelyapi.NewTracer(otel.Tracer("yapi"))))
+package otelyapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	yapi "go-yapi"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer implements yapi.Tracer with an OpenTelemetry tracer
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer returns an adapter of tracer, the global propagator injects headers
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// WithPropagator uses propagator instead of the global one
+func (t *Tracer) WithPropagator(propagator propagation.TextMapPropagator) *Tracer {
+	c := *t
+	c.propagator = propagator
+	return &c
+}
+
+// Start implements yapi.Tracer, the span is of client kind
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, yapi.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, Span{span: span}
+}
+
+// Inject implements yapi.Tracer
+func (t *Tracer) Inject(ctx context.Context, header http.Header) {
+	propagator := t.propagator
+	if propagator == nil {
+		propagator = otel.GetTextMapPropagator()
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Span implements yapi.Span with an OpenTelemetry span
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes implements yapi.Span
+func (s Span) SetAttributes(attrs ...yapi.Attribute) {
+	kv := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		kv = append(kv, keyValue(a))
+	}
+	s.span.SetAttributes(kv...)
+}
+
+// End implements yapi.Span, a non nil err is recorded and sets the error status
+func (s Span) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+func keyValue(a yapi.Attribute) attribute.KeyValue {
+	switch v := a.Value.(type) {
+	case string:
+		return attribute.String(a.Key, v)
+	case int:
+		return attribute.Int(a.Key, v)
+	case int64:
+		return attribute.Int64(a.Key, v)
+	case bool:
+		return attribute.Bool(a.Key, v)
+	case float64:
+		return attribute.Float64(a.Key, v)
+	default:
+		return attribute.String(a.Key, fmt.Sprint(v))
+	}
+}
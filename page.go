@@ -0,0 +1,90 @@
+package go_yapi
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Page is one page of a list returned by the API
+type Page[T any] struct {
+	Page    int   `json:"page"`
+	Total   int   `json:"total"`
+	PerPage int   `json:"per_page"`
+	Result  []T   `json:"result"`
+	Pages   int   `json:"pages"`
+	Links   Links `json:"links"`
+}
+
+// Links are URLs of the neighbour pages, empty if there is no such page
+type Links struct {
+	Next  string `json:"next"`
+	Prev  string `json:"prev"`
+	Last  string `json:"last"`
+	First string `json:"first"`
+}
+
+// HasNext reports whether there is a page after this one
+func (p Page[T]) HasNext() bool {
+	if p.Pages > 0 {
+		return p.current() < p.Pages
+	}
+	return p.Links.Next != ""
+}
+
+// NextParams returns the parameters selecting the next page, nil if there is none.
+// They are to be merged with the parameters of this page.
+// The page number is used when Pages is known, otherwise the query of Links.Next.
+func (p Page[T]) NextParams() Parameters {
+	if !p.HasNext() {
+		return nil
+	}
+	if p.Pages > 0 {
+		return Parameters{"page": []string{strconv.Itoa(p.current() + 1)}}
+	}
+	u, err := url.Parse(p.Links.Next)
+	if err != nil {
+		return nil
+	}
+	return ParametersFromValues(u.Query())
+}
+
+func (p Page[T]) current() int {
+	if p.Page == 0 {
+		return 1
+	}
+	return p.Page
+}
+
+// list gets one page of the list at ep
+func list[T any](ctx context.Context, d Directory, ep endpoint, orgID int, params Parameters) (Page[T], error) {
+	var page Page[T]
+	err := d.get(
+		ctx,
+		ep,
+		params,
+		orgID,
+		&page,
+	)
+	return page, err
+}
+
+// get gets one object at ep
+func get[T any](ctx context.Context, d Directory, ep endpoint, orgID int, params Parameters) (T, error) {
+	var v T
+	err := d.get(
+		ctx,
+		ep,
+		params,
+		orgID,
+		&v,
+	)
+	return v, err
+}
+
+// listPages fetches pages of the list at ep, any list gets pagination with it
+func listPages[T any](d Directory, ep endpoint, orgID int) pageFetcher[T] {
+	return func(ctx context.Context, params Parameters) (Page[T], error) {
+		return list[T](ctx, d, ep, orgID, params)
+	}
+}
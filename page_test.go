@@ -0,0 +1,28 @@
+package go_yapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPageNext(t *testing.T) {
+	p := Page[int]{Page: 2, Pages: 3}
+	if !p.HasNext() || !reflect.DeepEqual(p.NextParams(), Parameters{"page": []string{"3"}}) {
+		t.Errorf("wrong next of %+v: %v", p, p.NextParams())
+	}
+
+	p = Page[int]{Page: 3, Pages: 3, Links: Links{Next: "https://x/users/?page=4"}}
+	if p.HasNext() || p.NextParams() != nil {
+		t.Errorf("last page %+v has next", p)
+	}
+
+	p = Page[int]{Links: Links{Next: "https://x/users/?page=2&per_page=10"}}
+	if !p.HasNext() || !reflect.DeepEqual(p.NextParams(), Parameters{"page": []string{"2"}, "per_page": []string{"10"}}) {
+		t.Errorf("wrong next of %+v: %v", p, p.NextParams())
+	}
+
+	var users DirectoryUsers = Page[DirectoryUser]{}
+	if users.HasNext() {
+		t.Error("empty page has next")
+	}
+}
@@ -0,0 +1,255 @@
+package go_yapi
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strconv"
+	"sync"
+)
+
+// ErrStop may be returned by an Each* callback to stop the iteration without an error
+var ErrStop = errors.New("stop iteration")
+
+// pageFetcher gets one page of a list for the given parameters
+type pageFetcher[T any] func(ctx context.Context, params Parameters) (Page[T], error)
+
+// eachItem calls fn for every item of every page starting from the page in params.
+// Pages are requested by Page.NextParams, the base URL is kept.
+// The page number is the one requested, not the one the server reports,
+// and the iteration ends when the next page would not move forward.
+func eachItem[T any](ctx context.Context, fetch pageFetcher[T], params Parameters, fn func(T) error) error {
+	requested := requestedPage(params)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		page, err := fetch(ctx, params)
+		if err != nil {
+			return err
+		}
+		for i := range page.Result {
+			if err = fn(page.Result[i]); err != nil {
+				if errors.Is(err, ErrStop) {
+					return nil
+				}
+				return err
+			}
+		}
+		page.Page = requested
+		if len(page.Result) == 0 || !page.HasNext() {
+			return nil
+		}
+		next := params.Merge(page.NextParams())
+		if _, ok := next["page"]; ok {
+			if requestedPage(next) <= requested {
+				return nil
+			}
+			requested = requestedPage(next)
+		} else if next.String() == params.String() {
+			return nil
+		}
+		params = next
+	}
+}
+
+// requestedPage returns the page number params ask for, the first page by default
+func requestedPage(params Parameters) int {
+	if v := params["page"]; len(v) > 0 {
+		if n, err := strconv.Atoi(v[0]); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
+
+// allItems is eachItem as an iterator, an error is yielded with the zero item and ends the iteration
+func allItems[T any](ctx context.Context, fetch pageFetcher[T], params Parameters) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		err := eachItem(ctx, fetch, params, func(item T) error {
+			if !yield(item, nil) {
+				return ErrStop
+			}
+			return nil
+		})
+		if err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// chanItems is eachItem sending to a channel. Both channels are closed at the end,
+// the error channel gets at most one error. Cancel ctx to stop reading early.
+func chanItems[T any](ctx context.Context, fetch pageFetcher[T], params Parameters) (<-chan T, <-chan error) {
+	items := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(items)
+		err := eachItem(ctx, fetch, params, func(item T) error {
+			select {
+			case items <- item:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return items, errs
+}
+
+// fetchItems collects items of all pages. When the first page reports Pages,
+// the remaining pages are fetched by workers in parallel, the items keep page order.
+// The first error cancels the pages in flight and is returned.
+func fetchItems[T any](ctx context.Context, fetch pageFetcher[T], params Parameters, workers int) ([]T, error) {
+	var all []T
+	collect := func(item T) error {
+		all = append(all, item)
+		return nil
+	}
+	if workers <= 1 {
+		return all, eachItem(ctx, fetch, params, collect)
+	}
+
+	first, err := fetch(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	first.Page = requestedPage(params)
+	all = append(all, first.Result...)
+	if len(first.Result) == 0 || !first.HasNext() {
+		return all, nil
+	}
+	if first.Pages == 0 {
+		// the count is unknown, the rest is followed by links
+		return all, eachItem(ctx, fetch, params.Merge(first.NextParams()), collect)
+	}
+	start := first.Page
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		pages    = make([][]T, first.Pages-start)
+		next     = make(chan int)
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range next {
+				p, err := fetch(ctx, params.Merge(Parameters{"page": []string{strconv.Itoa(page)}}))
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+				pages[page-start-1] = p.Result
+			}
+		}()
+	}
+feed:
+	for page := start + 1; page <= first.Pages; page++ {
+		select {
+		case next <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	for i := range pages {
+		all = append(all, pages[i]...)
+	}
+	return all, nil
+}
+
+func (d Directory) userPages(orgID int) pageFetcher[DirectoryUser] {
+	return listPages[DirectoryUser](d, d.endpoint("/users/"), orgID)
+}
+
+func (d Directory) departmentPages(orgID int) pageFetcher[DirectoryDepartment] {
+	return listPages[DirectoryDepartment](d, d.endpoint("/departments/"), orgID)
+}
+
+func (d Directory) groupPages(orgID int) pageFetcher[DirectoryGroup] {
+	return listPages[DirectoryGroup](d, d.endpoint("/groups/"), orgID)
+}
+
+// EachUser calls fn for every user of all pages, see ErrStop
+func (d Directory) EachUser(ctx context.Context, orgID int, opts ListUsersOptions, fn func(DirectoryUser) error) error {
+	return eachItem(ctx, d.userPages(orgID), opts.Parameters(), fn)
+}
+
+// AllUsers iterates over users of all pages:
+//
+//	for user, err := range directory.AllUsers(ctx, orgID, opts) {
+func (d Directory) AllUsers(ctx context.Context, orgID int, opts ListUsersOptions) iter.Seq2[DirectoryUser, error] {
+	return allItems(ctx, d.userPages(orgID), opts.Parameters())
+}
+
+// UsersChan sends users of all pages to the channel, cancel ctx to stop early
+func (d Directory) UsersChan(ctx context.Context, orgID int, opts ListUsersOptions) (<-chan DirectoryUser, <-chan error) {
+	return chanItems(ctx, d.userPages(orgID), opts.Parameters())
+}
+
+// ListAllUsers returns users of all pages, the pages after the first one are fetched by workers in parallel
+func (d Directory) ListAllUsers(ctx context.Context, orgID int, opts ListUsersOptions, workers int) ([]DirectoryUser, error) {
+	return fetchItems(ctx, d.userPages(orgID), opts.Parameters(), workers)
+}
+
+// EachDepartment calls fn for every department of all pages, see ErrStop
+func (d Directory) EachDepartment(ctx context.Context, orgID int, opts ListDepartmentsOptions, fn func(DirectoryDepartment) error) error {
+	return eachItem(ctx, d.departmentPages(orgID), opts.Parameters(), fn)
+}
+
+// AllDepartments iterates over departments of all pages
+func (d Directory) AllDepartments(ctx context.Context, orgID int, opts ListDepartmentsOptions) iter.Seq2[DirectoryDepartment, error] {
+	return allItems(ctx, d.departmentPages(orgID), opts.Parameters())
+}
+
+// DepartmentsChan sends departments of all pages to the channel, cancel ctx to stop early
+func (d Directory) DepartmentsChan(ctx context.Context, orgID int, opts ListDepartmentsOptions) (<-chan DirectoryDepartment, <-chan error) {
+	return chanItems(ctx, d.departmentPages(orgID), opts.Parameters())
+}
+
+// ListAllDepartments returns departments of all pages, the pages after the first one are fetched by workers in parallel
+func (d Directory) ListAllDepartments(ctx context.Context, orgID int, opts ListDepartmentsOptions, workers int) ([]DirectoryDepartment, error) {
+	return fetchItems(ctx, d.departmentPages(orgID), opts.Parameters(), workers)
+}
+
+// EachGroup calls fn for every group of all pages, see ErrStop
+func (d Directory) EachGroup(ctx context.Context, orgID int, opts ListGroupsOptions, fn func(DirectoryGroup) error) error {
+	return eachItem(ctx, d.groupPages(orgID), opts.Parameters(), fn)
+}
+
+// AllGroups iterates over groups of all pages
+func (d Directory) AllGroups(ctx context.Context, orgID int, opts ListGroupsOptions) iter.Seq2[DirectoryGroup, error] {
+	return allItems(ctx, d.groupPages(orgID), opts.Parameters())
+}
+
+// GroupsChan sends groups of all pages to the channel, cancel ctx to stop early
+func (d Directory) GroupsChan(ctx context.Context, orgID int, opts ListGroupsOptions) (<-chan DirectoryGroup, <-chan error) {
+	return chanItems(ctx, d.groupPages(orgID), opts.Parameters())
+}
+
+// ListAllGroups returns groups of all pages, the pages after the first one are fetched by workers in parallel
+func (d Directory) ListAllGroups(ctx context.Context, orgID int, opts ListGroupsOptions, workers int) ([]DirectoryGroup, error) {
+	return fetchItems(ctx, d.groupPages(orgID), opts.Parameters(), workers)
+}
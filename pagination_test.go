@@ -0,0 +1,206 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagesServer serves users 1..total in pages of 2,
+// without pages count the next page is reported only by links.next
+func pagesServer(t *testing.T, total int, withPages bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if r.URL.Query().Get("group_id") != "5" {
+			t.Errorf("options are lost on page %d: %s", page, r.URL.RawQuery)
+		}
+		pages := (total + 1) / 2
+		var resp DirectoryUsers
+		resp.Page = page
+		for id := page*2 - 1; id <= page*2 && id <= total; id++ {
+			resp.Result = append(resp.Result, DirectoryUser{ID: id})
+		}
+		if withPages {
+			resp.Pages = pages
+		}
+		if page < pages {
+			resp.Links.Next = "https://api.directory.yandex.net/v6/users/?group_id=5&page=" + strconv.Itoa(page+1)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestAllUsers(t *testing.T) {
+	ctx := context.Background()
+	opts := ListUsersOptions{GroupID: 5}
+	for _, withPages := range []bool{true, false} {
+		srv := pagesServer(t, 5, withPages)
+		d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+		var ids []int
+		err := d.EachUser(ctx, 1, opts, func(u DirectoryUser) error {
+			ids = append(ids, u.ID)
+			return nil
+		})
+		if err != nil || len(ids) != 5 || ids[4] != 5 {
+			t.Errorf("callback got %v, '%v'", ids, err)
+		}
+
+		ids = ids[:0]
+		for u, err := range d.AllUsers(ctx, 1, opts) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, u.ID)
+			if len(ids) == 3 {
+				break
+			}
+		}
+		if len(ids) != 3 {
+			t.Errorf("iterator must stop after break but got %v", ids)
+		}
+
+		users, errs := d.UsersChan(ctx, 1, opts)
+		n := 0
+		for range users {
+			n++
+		}
+		if err := <-errs; err != nil || n != 5 {
+			t.Errorf("channel got %d users, '%v'", n, err)
+		}
+		srv.Close()
+	}
+}
+
+func TestAllUsersError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(DirectoryUsers{Page: 1, Pages: 3, Result: []DirectoryUser{{ID: 1}}})
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	var last error
+	n := 0
+	for _, err := range d.AllUsers(context.Background(), 1, ListUsersOptions{}) {
+		n++
+		last = err
+	}
+	if n != 2 || !IsStatus(last, http.StatusInternalServerError) {
+		t.Errorf("need 1 user and error but got %d items, '%v'", n, last)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := d.EachUser(ctx, 1, ListUsersOptions{}, func(DirectoryUser) error { return nil }); err != context.Canceled {
+		t.Errorf("need context.Canceled but got '%v'", err)
+	}
+}
+
+func TestListAllUsersParallel(t *testing.T) {
+	srv := pagesServer(t, 9, true)
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	users, err := d.ListAllUsers(context.Background(), 1, ListUsersOptions{GroupID: 5}, 3)
+	if err != nil || len(users) != 9 {
+		t.Fatalf("got %d users, '%v'", len(users), err)
+	}
+	for i := range users {
+		if users[i].ID != i+1 {
+			t.Fatalf("users are not in page order: %v", users)
+		}
+	}
+}
+
+func TestListAllUsersParallelError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "3" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(DirectoryUsers{Page: 1, Pages: 20, Result: []DirectoryUser{{ID: 1}}})
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if users, err := d.ListAllUsers(context.Background(), 1, ListUsersOptions{}, 4); !IsStatus(err, http.StatusBadRequest) || users != nil {
+		t.Errorf("need bad request error but got %d users, '%v'", len(users), err)
+	}
+}
+
+func TestEachUserWrappedStop(t *testing.T) {
+	srv := pagesServer(t, 5, true)
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	var ids []int
+	err := d.EachUser(context.Background(), 1, ListUsersOptions{GroupID: 5}, func(u DirectoryUser) error {
+		ids = append(ids, u.ID)
+		if u.ID == 3 {
+			return fmt.Errorf("found: %w", ErrStop)
+		}
+		return nil
+	})
+	if err != nil || fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("got %v, '%v'", ids, err)
+	}
+}
+
+func TestEachUserIgnoresServerPage(t *testing.T) {
+	for _, name := range []string{"no page", "stale page", "stale link"} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page == 0 {
+				page = 1
+			}
+			resp := DirectoryUsers{Result: []DirectoryUser{{ID: page}}}
+			switch name {
+			case "no page":
+				resp.Pages = 3
+			case "stale page":
+				resp.Page, resp.Pages = 1, 3
+			case "stale link":
+				resp.Links.Next = "https://api.directory.yandex.net/v6/users/?group_id=5&page=2"
+			}
+			json.NewEncoder(w).Encode(resp)
+		}))
+		d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+
+		var ids []int
+		err := d.EachUser(ctx, 1, ListUsersOptions{GroupID: 5}, func(u DirectoryUser) error {
+			ids = append(ids, u.ID)
+			return nil
+		})
+		need := "[1 2 3]"
+		if name == "stale link" {
+			need = "[1 2]"
+		}
+		if err != nil || fmt.Sprint(ids) != need {
+			t.Errorf("%s: got %v, '%v' in %d calls", name, ids, err, calls.Load())
+		}
+		if name != "stale link" {
+			users, err := d.ListAllUsers(ctx, 1, ListUsersOptions{GroupID: 5}, 2)
+			if err != nil || len(users) != 3 {
+				t.Errorf("%s: list all got %v, '%v'", name, users, err)
+			}
+		}
+		cancel()
+		srv.Close()
+	}
+}
@@ -0,0 +1,109 @@
+package go_yapi
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Parameters are query parameters of a call, several values of a key are comma-joined
+// unless the key is listed in QueryEncoder.Repeat
+type Parameters map[string][]string
+
+// String returns the query with leading "?" encoded by DefaultQueryEncoder, or empty string
+func (p Parameters) String() string {
+	if q := DefaultQueryEncoder.Encode(p); q != "" {
+		return "?" + q
+	}
+	return ""
+}
+
+// Values converts the parameters to url.Values, one value per element
+func (p Parameters) Values() url.Values {
+	v := make(url.Values, len(p))
+	for k := range p {
+		v[k] = append([]string(nil), p[k]...)
+	}
+	return v
+}
+
+// ParametersFromValues converts url.Values, e.g. a parsed query, to Parameters
+func ParametersFromValues(v url.Values) Parameters {
+	p := make(Parameters, len(v))
+	for k := range v {
+		p[k] = append([]string(nil), v[k]...)
+	}
+	return p
+}
+
+// Merge returns a copy of p with keys of other added, other wins on conflicts
+func (p Parameters) Merge(other Parameters) Parameters {
+	m := make(Parameters, len(p)+len(other))
+	for k := range p {
+		m[k] = append([]string(nil), p[k]...)
+	}
+	for k := range other {
+		m[k] = append([]string(nil), other[k]...)
+	}
+	return m
+}
+
+// DefaultQueryEncoder comma-joins all values
+var DefaultQueryEncoder = QueryEncoder{}
+
+// QueryEncoder encodes Parameters deterministically: keys are sorted, keys and values are escaped.
+type QueryEncoder struct {
+	// Repeat lists keys sent as repeated key=value pairs instead of one comma-joined value
+	Repeat map[string]bool
+}
+
+// Encode returns the query without leading "?"
+func (e QueryEncoder) Encode(p Parameters) string {
+	if len(p) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	q := &strings.Builder{}
+	add := func(k, v string) {
+		if q.Len() > 0 {
+			q.WriteByte('&')
+		}
+		q.WriteString(url.QueryEscape(k) + "=" + v)
+	}
+	for _, k := range keys {
+		if e.Repeat[k] {
+			for _, v := range p[k] {
+				add(k, url.QueryEscape(v))
+			}
+			continue
+		}
+		d := make([]string, 0, len(p[k]))
+		for i := range p[k] {
+			d = append(d, url.QueryEscape(p[k][i]))
+		}
+		add(k, strings.Join(d, ","))
+	}
+	return q.String()
+}
+
+// MergeURL adds p to the query already present in rawURL, e.g. Links.Next of a page.
+// Keys of p replace the same keys of the URL, the whole query is encoded by e.
+func (e QueryEncoder) MergeURL(rawURL string, p Parameters) (string, error) {
+	if !strings.Contains(rawURL, "?") {
+		if q := e.Encode(p); q != "" {
+			return rawURL + "?" + q, nil
+		}
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = e.Encode(ParametersFromValues(u.Query()).Merge(p))
+	return u.String(), nil
+}
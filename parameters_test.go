@@ -0,0 +1,44 @@
+package go_yapi
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestParametersString(t *testing.T) {
+	p := Parameters{
+		"per_page": []string{"10"},
+		"fields":   []string{"id", "name"},
+		"a b":      []string{"x&y"},
+	}
+	for i := 0; i < 10; i++ {
+		if s := p.String(); s != "?a+b=x%26y&fields=id,name&per_page=10" {
+			t.Fatalf("got '%s'", s)
+		}
+	}
+	if s := (Parameters{}).String(); s != "" {
+		t.Errorf("empty parameters encoded as '%s'", s)
+	}
+
+	e := QueryEncoder{Repeat: map[string]bool{"id": true}}
+	if s := e.Encode(Parameters{"id": []string{"1", "2"}, "fields": []string{"id", "name"}}); s != "fields=id,name&id=1&id=2" {
+		t.Errorf("got '%s'", s)
+	}
+}
+
+func TestMergeURL(t *testing.T) {
+	next := "https://api.directory.yandex.net/v6/users/?page=2&per_page=20"
+	u, err := DefaultQueryEncoder.MergeURL(next, Parameters{"per_page": []string{"50"}, "fields": []string{"id"}})
+	if err != nil || u != "https://api.directory.yandex.net/v6/users/?fields=id&page=2&per_page=50" {
+		t.Errorf("got '%s', '%v'", u, err)
+	}
+	if u, _ = DefaultQueryEncoder.MergeURL("https://x/users/", nil); u != "https://x/users/" {
+		t.Errorf("got '%s'", u)
+	}
+
+	v := url.Values{"id": []string{"1", "2"}}
+	if p := ParametersFromValues(v); !reflect.DeepEqual(p.Values(), v) {
+		t.Errorf("got %v", p.Values())
+	}
+}
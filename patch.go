@@ -0,0 +1,99 @@
+package go_yapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// String returns a pointer to s, for fields of patch types
+func String(s string) *string { return &s }
+
+// Bool returns a pointer to b, for fields of patch types
+func Bool(b bool) *bool { return &b }
+
+// Int returns a pointer to i, for fields of patch types
+func Int(i int) *int { return &i }
+
+// DirectoryUserPatch is a partial update of a user: only non-nil fields are sent,
+// so false, zero and empty values can be set too
+type DirectoryUserPatch struct {
+	Name                   *DirectoryUserName      `json:"name,omitempty"`
+	Position               *string                 `json:"position,omitempty"`
+	About                  *string                 `json:"about,omitempty"`
+	Gender                 *string                 `json:"gender,omitempty"`
+	Birthday               *string                 `json:"birthday,omitempty"`
+	DepartmentID           *int                    `json:"department_id,omitempty"`
+	Contacts               *[]DirectoryUserContact `json:"contacts,omitempty"`
+	ExternalID             *string                 `json:"external_id,omitempty"`
+	IsAdmin                *bool                   `json:"is_admin,omitempty"`
+	IsDismissed            *bool                   `json:"is_dismissed,omitempty"`
+	Password               *string                 `json:"password,omitempty"`
+	PasswordChangeRequired *bool                   `json:"password_change_required,omitempty"`
+}
+
+// DirectoryDepartmentPatch is a partial update of a department, only non-nil fields are sent
+type DirectoryDepartmentPatch struct {
+	Name        *string `json:"name,omitempty"`
+	Label       *string `json:"label,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ParentID    *int    `json:"parent_id,omitempty"`
+	HeadID      *int    `json:"head_id,omitempty"`
+	ExternalID  *string `json:"external_id,omitempty"`
+}
+
+// DirectoryGroupPatch is a partial update of a group, only non-nil fields are sent
+type DirectoryGroupPatch struct {
+	Name        *string                 `json:"name,omitempty"`
+	Label       *string                 `json:"label,omitempty"`
+	Description *string                 `json:"description,omitempty"`
+	ExternalID  *string                 `json:"external_id,omitempty"`
+	Members     *[]DirectoryGroupMember `json:"members,omitempty"`
+	Admins      *[]DirectoryGroupAdmin  `json:"admins,omitempty"`
+}
+
+// PatchUser ...
+func (d Directory) PatchUser(orgID, userID int, patch DirectoryUserPatch) (DirectoryUser, error) {
+	return d.PatchUserContext(context.Background(), orgID, userID, patch)
+}
+
+// PatchUserContext ...
+func (d Directory) PatchUserContext(ctx context.Context, orgID, userID int, patch DirectoryUserPatch) (DirectoryUser, error) {
+	return patchObject[DirectoryUser](ctx, d, d.endpoint("/users/{id}/", userID), orgID, patch)
+}
+
+// PatchDepartment ...
+func (d Directory) PatchDepartment(orgID, depID int, patch DirectoryDepartmentPatch) (DirectoryDepartment, error) {
+	return d.PatchDepartmentContext(context.Background(), orgID, depID, patch)
+}
+
+// PatchDepartmentContext ...
+func (d Directory) PatchDepartmentContext(ctx context.Context, orgID, depID int, patch DirectoryDepartmentPatch) (DirectoryDepartment, error) {
+	return patchObject[DirectoryDepartment](ctx, d, d.endpoint("/departments/{id}/", depID), orgID, patch)
+}
+
+// PatchGroup ...
+func (d Directory) PatchGroup(orgID, groupID int, patch DirectoryGroupPatch) (DirectoryGroup, error) {
+	return d.PatchGroupContext(context.Background(), orgID, groupID, patch)
+}
+
+// PatchGroupContext ...
+func (d Directory) PatchGroupContext(ctx context.Context, orgID, groupID int, patch DirectoryGroupPatch) (DirectoryGroup, error) {
+	return patchObject[DirectoryGroup](ctx, d, d.endpoint("/groups/{id}/", groupID), orgID, patch)
+}
+
+func patchObject[T any](ctx context.Context, d Directory, ep endpoint, orgID int, patch interface{}) (T, error) {
+	var v T
+	j, err := json.Marshal(patch)
+	if err != nil {
+		return v, err
+	}
+	err = d.patch(
+		ctx,
+		ep,
+		nil,
+		orgID,
+		j,
+		&v,
+	)
+	return v, err
+}
@@ -0,0 +1,40 @@
+package go_yapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPatchJSON(t *testing.T) {
+	b, _ := json.Marshal(DirectoryUserPatch{IsDismissed: Bool(false), IsAdmin: Bool(false), Position: String("")})
+	if string(b) != `{"position":"","is_admin":false,"is_dismissed":false}` {
+		t.Errorf("user patch encoded as %s", b)
+	}
+	b, _ = json.Marshal(DirectoryDepartmentPatch{HeadID: Int(0)})
+	if string(b) != `{"head_id":0}` {
+		t.Errorf("department patch encoded as %s", b)
+	}
+	b, _ = json.Marshal(DirectoryGroupPatch{})
+	if string(b) != `{}` {
+		t.Errorf("empty group patch encoded as %s", b)
+	}
+}
+
+func TestPatchUser(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPatch || r.URL.Path != "/users/7/" || string(body) != `{"is_dismissed":false}` {
+			t.Errorf("wrong request %s %s %s", r.Method, r.URL.Path, body)
+		}
+		w.Write([]byte(`{"id": 7}`))
+	}))
+	defer srv.Close()
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL))
+
+	if user, err := d.PatchUser(1, 7, DirectoryUserPatch{IsDismissed: Bool(false)}); err != nil || user.ID != 7 {
+		t.Errorf("got %v, '%v'", user, err)
+	}
+}
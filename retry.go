@@ -0,0 +1,140 @@
+package go_yapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how Directory repeats failed calls.
+// Only network errors, 429 and transient 5xx statuses are retried.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the second attempt, it doubles with every next attempt
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and the Retry-After value sent by the server
+	MaxDelay time.Duration
+	// Jitter is the fraction (0..1) of the delay that is randomized
+	Jitter float64
+	// RetryPost allows to retry POST and PATCH calls which are not idempotent
+	RetryPost bool
+}
+
+// DefaultRetryPolicy is a reasonable policy for background jobs
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryInterceptor repeats failed calls according to policy,
+// the call is rewound before every attempt.
+func RetryInterceptor(policy RetryPolicy) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			for attempt := 1; ; attempt++ {
+				call.Attempt = attempt
+				err := next(ctx, call)
+				if err == nil || !policy.shouldRetry(ctx, call.Request.Method, attempt, err) {
+					return err
+				}
+				if err = sleepContext(ctx, policy.delay(attempt, err)); err != nil {
+					return err
+				}
+				if err = call.rewind(ctx); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	case http.MethodPost, http.MethodPatch:
+		if !p.RetryPost {
+			return false
+		}
+	default:
+		return false
+	}
+	var e *APIError
+	if !errors.As(err, &e) {
+		// transport error, the request may not have reached the server
+		return true
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns the pause before the attempt following the given one
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	var e *APIError
+	if errors.As(err, &e) {
+		if d, ok := retryAfter(e.Header.Get("Retry-After")); ok {
+			if p.MaxDelay > 0 && d > p.MaxDelay {
+				d = p.MaxDelay
+			}
+			return d
+		}
+	}
+
+	d := p.BaseDelay << uint(attempt-1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 && d > 0 {
+		j := time.Duration(p.Jitter * float64(d))
+		d = d - j + time.Duration(rand.Int63n(int64(2*j)+1))
+	}
+	return d
+}
+
+// retryAfter parses Retry-After header given as seconds or as HTTP date
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
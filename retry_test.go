@@ -0,0 +1,71 @@
+package go_yapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		if r.Method == http.MethodPost && !strings.Contains(string(body), "New") {
+			t.Errorf("attempt %d got empty body", n)
+		}
+		switch n {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			fmt.Fprint(w, `{"id": 7}`)
+		}
+	}))
+	defer srv.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, Jitter: 0.5}
+
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithRetryPolicy(policy))
+	dep, err := d.GetDepartment(1, 7, nil)
+	if err != nil || dep.ID != 7 || calls != 3 {
+		t.Errorf("need department after 3 calls but got %v, '%v' after %d calls", dep, err, calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	_, err = d.CreateDepartment(1, DirectoryNewDepartment{Name: "New"})
+	if !IsStatus(err, http.StatusTooManyRequests) || calls != 1 {
+		t.Errorf("POST must not be retried by default, got '%v' after %d calls", err, calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	policy.RetryPost = true
+	d = NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithRetryPolicy(policy))
+	dep, err = d.CreateDepartment(1, DirectoryNewDepartment{Name: "New"})
+	if err != nil || dep.ID != 7 || calls != 3 {
+		t.Errorf("need created department after 3 calls but got '%v' after %d calls", err, calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("3"); !ok || d != 3*time.Second {
+		t.Errorf("'3' parsed as %v", d)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("'soon' must not be parsed")
+	}
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 3 * time.Second}
+	if d := p.delay(5, nil); d != 3*time.Second {
+		t.Errorf("delay must be capped by MaxDelay but got %v", d)
+	}
+}
@@ -0,0 +1,150 @@
+package go_yapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Tracer starts a span around every Directory call, see WithTracer
+type Tracer interface {
+	// Start starts a span, the returned context carries it to the HTTP request
+	Start(ctx context.Context, name string) (context.Context, Span)
+	// Inject writes the propagation headers of the span in ctx to header
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is one traced call
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// End finishes the span, err is nil if the call succeeded
+	End(err error)
+}
+
+// Attribute is a key-value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span attribute keys set by TracingInterceptor
+const (
+	AttrMethod     = "http.method"
+	AttrURL        = "http.url"
+	AttrStatusCode = "http.status_code"
+	AttrOrgID      = "yapi.org_id"
+	AttrEndpoint   = "yapi.endpoint"
+	AttrAttempts   = "yapi.attempts"
+)
+
+// WithTracer traces every call of the Directory.
+// The span covers all attempts of the call.
+func WithTracer(tracer Tracer) DirectoryOption {
+	return func(d *Directory) {
+		d.tracer = tracer
+	}
+}
+
+// TracingInterceptor starts a span named "METHOD /endpoint/{template}" for every call passed through it
+func TracingInterceptor(tracer Tracer) Interceptor {
+	return func(next RoundTrip) RoundTrip {
+		return func(ctx context.Context, call *Call) error {
+			name := call.Endpoint
+			if name == "" {
+				name = call.Request.URL.Path
+			}
+			ctx, span := tracer.Start(ctx, call.Request.Method+" "+name)
+			call.Request = call.Request.WithContext(ctx)
+			tracer.Inject(ctx, call.Request.Header)
+			span.SetAttributes(
+				Attribute{Key: AttrMethod, Value: call.Request.Method},
+				Attribute{Key: AttrURL, Value: call.Request.URL.String()},
+				Attribute{Key: AttrOrgID, Value: call.OrgID},
+				Attribute{Key: AttrEndpoint, Value: name},
+			)
+
+			err := next(ctx, call)
+
+			attrs := []Attribute{{Key: AttrAttempts, Value: call.Attempt}}
+			if call.Response != nil {
+				attrs = append(attrs, Attribute{Key: AttrStatusCode, Value: call.Response.StatusCode})
+			}
+			span.SetAttributes(attrs...)
+			span.End(err)
+			return err
+		}
+	}
+}
+
+// RecordingTracer keeps finished spans in memory, it is intended for tests
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// RecordedSpan is a span kept by RecordingTracer
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Started    time.Time
+	Finished   time.Time
+	Err        error
+	Ended      bool
+
+	tracer *RecordingTracer
+}
+
+type recordedSpanKey struct{}
+
+// Start implements Tracer
+func (t *RecordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &RecordedSpan{
+		Name:       name,
+		Attributes: map[string]interface{}{},
+		Started:    time.Now(),
+		tracer:     t,
+	}
+	return context.WithValue(ctx, recordedSpanKey{}, s), s
+}
+
+// Inject implements Tracer, it sets the X-Span-Name header
+func (t *RecordingTracer) Inject(ctx context.Context, header http.Header) {
+	if s, ok := ctx.Value(recordedSpanKey{}).(*RecordedSpan); ok {
+		header.Set("X-Span-Name", s.Name)
+	}
+}
+
+// Spans returns finished spans in the order they ended
+func (t *RecordingTracer) Spans() []*RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*RecordedSpan(nil), t.spans...)
+}
+
+// Reset forgets recorded spans
+func (t *RecordingTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+// SetAttributes implements Span
+func (s *RecordedSpan) SetAttributes(attrs ...Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, a := range attrs {
+		s.Attributes[a.Key] = a.Value
+	}
+}
+
+// End implements Span
+func (s *RecordedSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if s.Ended {
+		return
+	}
+	s.Finished, s.Err, s.Ended = time.Now(), err, true
+	s.tracer.spans = append(s.tracer.spans, s)
+}
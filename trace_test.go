@@ -0,0 +1,44 @@
+package go_yapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Span-Name") != "GET /departments/{id}/" {
+			t.Errorf("span is not propagated, header '%s'", r.Header.Get("X-Span-Name"))
+		}
+		if r.URL.Path == "/departments/404/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	defer srv.Close()
+
+	tracer := &RecordingTracer{}
+	d := NewDirectory(srv.Client(), WithBaseURL(srv.URL), WithTracer(tracer))
+	if _, err := d.GetDepartment(3, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetDepartment(3, 404, nil); !IsNotFound(err) {
+		t.Fatalf("need not found but got '%v'", err)
+	}
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("need 2 spans but got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "GET /departments/{id}/" || s.Err != nil || s.Attributes[AttrOrgID] != 3 ||
+		s.Attributes[AttrEndpoint] != "/departments/{id}/" || s.Attributes[AttrStatusCode] != http.StatusOK {
+		t.Errorf("wrong span %+v", s)
+	}
+	if s = spans[1]; !IsNotFound(s.Err) || s.Attributes[AttrStatusCode] != http.StatusNotFound {
+		t.Errorf("wrong failed span %+v", s)
+	}
+}
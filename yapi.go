@@ -1,7 +1,7 @@
 package go_yapi
 
 import (
-	"bytes"
+	"context"
 	"encoding/gob"
 	"encoding/json"
 	"errors"
@@ -9,14 +9,15 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"net/url"
 	"os"
 	"strconv"
-	"strings"
 )
 
 const VersionAPI = "v6"
 
+// Debug logs bodies of failed calls with the standard logger.
+//
+// Deprecated: use WithLogger to log calls of a Directory.
 var Debug = false
 
 // http://www.patorjk.com/software/taag/
@@ -40,86 +41,111 @@ func NewOauth2Config(clientID, clientSecret string, scopes []string) *oauth2.Con
 	}
 }
 
-type Parameters map[string][]string
-
-func (p Parameters) String() string {
-	if len(p) > 0 {
-		pURL := &strings.Builder{}
-		first := true
-		for k := range p {
-			if first {
-				pURL.WriteString("?")
-				first = false
-			} else {
-				pURL.WriteString("&")
-			}
-			d := make([]string, 0, len(p[k]))
-			for i := range p[k] {
-				d = append(d, url.QueryEscape(p[k][i]))
-			}
-			pURL.WriteString(k + "=" + strings.Join(d, ","))
-		}
-		return pURL.String()
-	}
-	return ""
-}
-
 // Get ...
 func Get(client *http.Client, url string, params Parameters, header map[string]string, v interface{}) error {
-	return Request(client, http.MethodGet, url, params, header, http.StatusOK, nil, v)
+	return GetContext(context.Background(), client, url, params, header, v)
+}
+
+// GetContext ...
+func GetContext(ctx context.Context, client *http.Client, url string, params Parameters, header map[string]string, v interface{}) error {
+	return RequestContext(ctx, client, http.MethodGet, url, params, header, http.StatusOK, nil, v)
 }
 
 // Post ...
 func Post(client *http.Client, url string, params Parameters, header map[string]string, body io.Reader, v interface{}) error {
-	return Request(client, http.MethodPost, url, params, header, http.StatusCreated, body, v)
+	return PostContext(context.Background(), client, url, params, header, body, v)
+}
+
+// PostContext ...
+func PostContext(ctx context.Context, client *http.Client, url string, params Parameters, header map[string]string, body io.Reader, v interface{}) error {
+	return RequestContext(ctx, client, http.MethodPost, url, params, header, http.StatusCreated, body, v)
 }
 
 // Patch ...
 func Patch(client *http.Client, url string, params Parameters, header map[string]string, body io.Reader, v interface{}) error {
-	return Request(client, http.MethodPatch, url, params, header, http.StatusOK, body, v)
+	return PatchContext(context.Background(), client, url, params, header, body, v)
+}
+
+// PatchContext ...
+func PatchContext(ctx context.Context, client *http.Client, url string, params Parameters, header map[string]string, body io.Reader, v interface{}) error {
+	return RequestContext(ctx, client, http.MethodPatch, url, params, header, http.StatusOK, body, v)
 }
 
 // Delete ...
 func Delete(client *http.Client, url string, params Parameters, header map[string]string) error {
-	return Request(client, http.MethodDelete, url, params, header, http.StatusNoContent, nil, nil)
+	return DeleteContext(context.Background(), client, url, params, header)
+}
+
+// DeleteContext ...
+func DeleteContext(ctx context.Context, client *http.Client, url string, params Parameters, header map[string]string) error {
+	return RequestContext(ctx, client, http.MethodDelete, url, params, header, http.StatusNoContent, nil, nil)
 }
 
 func Request(client *http.Client, method, url string, params Parameters, header map[string]string, expectedStatus int, body io.Reader, v interface{}) error {
-	req, err := http.NewRequest(method, url+params.String(), body)
+	return RequestContext(context.Background(), client, method, url, params, header, expectedStatus, body, v)
+}
+
+// RequestContext is Request bound to ctx, the call is aborted when ctx is cancelled or its deadline expires.
+func RequestContext(ctx context.Context, client *http.Client, method, url string, params Parameters, header map[string]string, expectedStatus int, body io.Reader, v interface{}) error {
+	req, err := newRequest(ctx, method, url, params, header, body)
+	if err != nil {
+		return err
+	}
+	_, respBody, err := roundTrip(client, req, expectedStatus)
 	if err != nil {
+		var e *APIError
+		if Debug && errors.As(err, &e) {
+			log.Print(redactBody(e.Body))
+		}
 		return err
 	}
+	return decodeBody(respBody, v)
+}
+
+func newRequest(ctx context.Context, method, url string, params Parameters, header map[string]string, body io.Reader) (*http.Request, error) {
+	return newRequestWithEncoder(ctx, DefaultQueryEncoder, method, url, params, header, body)
+}
+
+func newRequestWithEncoder(ctx context.Context, encoder QueryEncoder, method, url string, params Parameters, header map[string]string, body io.Reader) (*http.Request, error) {
+	u, err := encoder.MergeURL(url, params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
 	for k := range header {
 		req.Header.Add(k, header[k])
 	}
 	req.Header.Add("Content-Type", "application/json")
+	return req, nil
+}
 
+// roundTrip sends req and reads the whole response body.
+// A status other than expectedStatus is returned as *APIError.
+func roundTrip(client *http.Client, req *http.Request, expectedStatus int) (*http.Response, []byte, error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusForbidden {
-		return errors.New(resp.Status + " " + resp.Header.Get("WWW-Authenticate"))
-	}
-
 	if resp.StatusCode != expectedStatus {
-		if Debug {
-			buf := &bytes.Buffer{}
-			buf.ReadFrom(resp.Body)
-			log.Printf(buf.String())
-		}
-		return errors.New(resp.Status)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp, body, newAPIError(req, resp, body)
 	}
 
-	if v != nil {
-		err = json.NewDecoder(resp.Body).Decode(v)
-		if err != nil {
-			return err
-		}
+	body, err := io.ReadAll(resp.Body)
+	return resp, body, err
+}
+
+// decodeBody decodes the JSON body into v, an empty body leaves v as is
+func decodeBody(body []byte, v interface{}) error {
+	if v == nil || len(body) == 0 {
+		return nil
 	}
-	return nil
+	return json.Unmarshal(body, v)
 }
 
 func TokenFromFile(tokenFile string) (*oauth2.Token, error) {
@@ -1,7 +1,12 @@
 package go_yapi
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestJsonParam(t *testing.T) {
@@ -18,3 +23,18 @@ func TestJsonParam(t *testing.T) {
 	do(1.2, "1.2")
 	do(nil, "null")
 }
+
+func TestRequestContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := GetContext(ctx, srv.Client(), srv.URL, nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("need context.DeadlineExceeded but got '%v'", err)
+	}
+}